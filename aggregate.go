@@ -0,0 +1,157 @@
+package goMysql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func (b *builder) Count() (int64, error) {
+	value, err := b.aggregate("COUNT", "*")
+	if err != nil {
+		return 0, err
+	}
+
+	count, _ := value.(int64)
+	return count, nil
+}
+
+func (b *builder) Sum(column string) (float64, error) {
+	value, err := b.aggregate("SUM", column)
+	if err != nil {
+		return 0, err
+	}
+	return toFloat(value), nil
+}
+
+func (b *builder) Avg(column string) (float64, error) {
+	value, err := b.aggregate("AVG", column)
+	if err != nil {
+		return 0, err
+	}
+	return toFloat(value), nil
+}
+
+// * typed by column: int64, float64, string, time.Time, nil when no rows
+func (b *builder) Min(column string) (interface{}, error) {
+	return b.aggregate("MIN", column)
+}
+
+func (b *builder) Max(column string) (interface{}, error) {
+	return b.aggregate("MAX", column)
+}
+
+func (b *builder) Exists() (bool, error) {
+	clone := b.withoutOrder()
+	clone.selectList = []string{"1"}
+	clone.selectBindings = nil
+
+	if len(clone.groupList) > 0 || len(clone.havingList) > 0 || clone.distinct {
+		clone.selectList = b.selectList
+		clone.selectBindings = b.selectBindings
+	}
+
+	query, bindings, err := clone.toSQL()
+	if err != nil {
+		return false, err
+	}
+
+	value, err := b.scalar(fmt.Sprintf("SELECT EXISTS(%s) AS aggregate", query), bindings...)
+	if err != nil {
+		return false, err
+	}
+
+	exists, _ := value.(int64)
+	return exists == 1, nil
+}
+
+func (b *builder) DoesntExist() (bool, error) {
+	exists, err := b.Exists()
+	return !exists, err
+}
+
+// * private method
+// * ORDER BY, LIMIT, OFFSET and Total() do not affect aggregate result
+func (b *builder) withoutOrder() *builder {
+	clone := *b
+	clone.orderList = nil
+	clone.orderBindings = nil
+	clone.limit = nil
+	clone.offset = nil
+	clone.withTotal = false
+	return &clone
+}
+
+// * private method
+func (b *builder) aggregate(function, column string) (interface{}, error) {
+	clone := b.withoutOrder()
+
+	target := column
+	if column != "*" {
+		target = quoteColumn(column)
+	}
+
+	var query string
+	var bindings []interface{}
+	var err error
+
+	// * grouped or distinct rows are aggregated from a derived table
+	if len(clone.groupList) > 0 || len(clone.havingList) > 0 || clone.distinct {
+		var inner string
+		inner, bindings, err = clone.toSQL()
+		if err != nil {
+			return nil, err
+		}
+
+		if column != "*" {
+			target = quoteColumn(column[strings.LastIndex(column, ".")+1:])
+		}
+		query = fmt.Sprintf("SELECT %s(%s) AS aggregate FROM (%s) AS aggregate_table", function, target, inner)
+	} else {
+		clone.selectList = []string{fmt.Sprintf("%s(%s) AS aggregate", function, target)}
+		clone.selectBindings = nil
+
+		query, bindings, err = clone.toSQL()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b.scalar(query, bindings...)
+}
+
+// * private method
+func (b *builder) scalar(query string, bindings ...interface{}) (interface{}, error) {
+	rows, err := b.query(query, bindings...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list, err := scanMaps(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	return list[0]["aggregate"], nil
+}
+
+// * private method
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
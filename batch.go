@@ -0,0 +1,294 @@
+package goMysql
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	maxPlaceholders      = 65535
+	defaultMaxPacketSize = 4 * 1024 * 1024
+)
+
+type BatchResult struct {
+	RowsAffected  int64
+	FirstInsertID int64
+}
+
+type insertChunk struct {
+	query  string
+	values []interface{}
+}
+
+func (b *builder) InsertMany(rows []map[string]interface{}) (*BatchResult, error) {
+	return b.insertMany("INSERT", rows)
+}
+
+func (b *builder) InsertStructs(list interface{}) (*BatchResult, error) {
+	rows, err := b.structsToMaps(list)
+	if err != nil {
+		return nil, err
+	}
+	return b.InsertMany(rows)
+}
+
+// * private method
+func (b *builder) insertMany(verb string, rows []map[string]interface{}) (*BatchResult, error) {
+	if b.table == nil {
+		return nil, b.logger.Error(nil, "Table is required")
+	}
+
+	if len(rows) == 0 {
+		return &BatchResult{}, nil
+	}
+
+	chunks := b.insertChunks(verb, rows, b.maxPacketSize())
+
+	// * multiple statements run in one transaction, so a failed chunk leaves nothing behind
+	if len(chunks) > 1 {
+		var result *BatchResult
+		err := b.atomic(func(tb *builder) error {
+			var err error
+			result, err = tb.execChunks(chunks)
+			return err
+		})
+		return result, err
+	}
+
+	return b.execChunks(chunks)
+}
+
+// * private method
+// * inside a Tx the statements already share its transaction
+func (b *builder) atomic(fn func(tb *builder) error) error {
+	b.resolve()
+
+	db, ok := b.db.(*sql.DB)
+	if !ok {
+		return fn(b)
+	}
+
+	tx, err := db.BeginTx(b.ctx, nil)
+	if err != nil {
+		return b.logger.Error(err, "Failed to begin transaction")
+	}
+
+	txBuilder := *b
+	txBuilder.db = tx
+
+	if err := fn(&txBuilder); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return b.logger.Error(err, "Failed to commit transaction")
+	}
+
+	// * statements above only tracked time, GTID is known after commit
+	b.trackToken()
+	return nil
+}
+
+// * private method
+func (b *builder) execChunks(chunks []insertChunk) (*BatchResult, error) {
+	result := &BatchResult{}
+
+	for i, chunk := range chunks {
+		res, err := b.exec(chunk.query, chunk.values...)
+		if err != nil {
+			return nil, err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		result.RowsAffected += affected
+
+		if i == 0 {
+			// * first id generated by a multi-row insert
+			if result.FirstInsertID, err = res.LastInsertId(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// * private method
+// * split by both 65535 placeholders and max_allowed_packet
+func (b *builder) insertChunks(verb string, rows []map[string]interface{}, maxPacket int) []insertChunk {
+	columnSet := map[string]bool{}
+	for _, row := range rows {
+		for column := range row {
+			columnSet[column] = true
+		}
+	}
+
+	columns := make([]string, 0, len(columnSet))
+	for column := range columnSet {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = fmt.Sprintf("`%s`", column)
+	}
+
+	prefix := fmt.Sprintf("%s INTO %s (%s) VALUES ", verb, b.tableName(), strings.Join(quoted, ", "))
+
+	chunks := []insertChunk{}
+	var current insertChunk
+	var rowList []string
+	size := len(prefix)
+
+	for _, row := range rows {
+		placeholders := make([]string, len(columns))
+		values := make([]interface{}, 0, len(columns))
+		rowSize := 4
+
+		for i, column := range columns {
+			value, ok := row[column]
+			if !ok {
+				// * missing column falls back to column default
+				placeholders[i] = "DEFAULT"
+				rowSize += 9
+				continue
+			}
+			valueSQL, bindings := placeholder(value)
+			placeholders[i] = valueSQL
+			values = append(values, bindings...)
+			rowSize += estimateSize(value) + len(valueSQL) + 2
+		}
+
+		full := len(current.values)+len(values) > maxPlaceholders || size+rowSize > maxPacket
+		if len(rowList) > 0 && full {
+			current.query = prefix + strings.Join(rowList, ", ")
+			chunks = append(chunks, current)
+			current = insertChunk{}
+			rowList = nil
+			size = len(prefix)
+		}
+
+		rowList = append(rowList, "("+strings.Join(placeholders, ", ")+")")
+		current.values = append(current.values, values...)
+		size += rowSize
+	}
+
+	current.query = prefix + strings.Join(rowList, ", ")
+	chunks = append(chunks, current)
+
+	return chunks
+}
+
+// * private method
+func (b *builder) maxPacketSize() int {
+	b.resolve()
+	if b.db == nil {
+		return defaultMaxPacketSize
+	}
+
+	var size int
+	rows, err := b.db.QueryContext(b.ctx, "SELECT @@max_allowed_packet")
+	if err == nil {
+		if rows.Next() {
+			err = rows.Scan(&size)
+		}
+		rows.Close()
+	}
+
+	if err != nil || size <= 0 {
+		size = defaultMaxPacketSize
+	}
+
+	// * keep headroom for protocol overhead
+	return size / 10 * 9
+}
+
+// * private method
+func estimateSize(value interface{}) int {
+	switch v := value.(type) {
+	case nil:
+		return 4
+	case string:
+		return len(v)
+	case []byte:
+		return len(v)
+	case Expression:
+		size := 0
+		for _, binding := range v.bindings {
+			size += estimateSize(binding)
+		}
+		return size
+	default:
+		return 24
+	}
+}
+
+// * private method
+func (b *builder) structsToMaps(list interface{}) ([]map[string]interface{}, error) {
+	value := reflect.ValueOf(list)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, b.logger.Error(nil, "Data must be a slice of struct")
+	}
+
+	rows := make([]map[string]interface{}, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		row, err := b.structToMap(value.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// * private method
+// * omits zero value fields tagged with omitempty, such as auto increment id
+func (b *builder) structToMap(value reflect.Value) (map[string]interface{}, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, b.logger.Error(nil, "Data must not be nil")
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, b.logger.Error(nil, "Data must be a struct")
+	}
+
+	row := map[string]interface{}{}
+	for column, field := range structFields(value.Type()) {
+		fieldValue, ok := readField(value, field.index)
+		if !ok || (field.omitEmpty && fieldValue.IsZero()) {
+			continue
+		}
+		row[column] = fieldValue.Interface()
+	}
+	return row, nil
+}
+
+// * private method
+// * unlike fieldByIndex, nil embedded pointer is skipped instead of allocated
+func readField(value reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+			value = value.Elem()
+		}
+		value = value.Field(x)
+	}
+	return value, true
+}
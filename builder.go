@@ -1,42 +1,63 @@
 package goMysql
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
 	"strings"
 )
 
-var (
-	supportFunction = []string{
-		"NOW()", "CURRENT_TIMESTAMP", "UUID()", "RAND()", "CURDATE()",
-		"CURTIME()", "UNIX_TIMESTAMP()", "UTC_TIMESTAMP()", "SYSDATE()",
-		"LOCALTIME()", "LOCALTIMESTAMP()", "PI()", "DATABASE()", "USER()",
-		"VERSION()",
+// * tables are qualified as `db`.`table`, pooled connections never switch schema
+// * read replica is picked once here, so every terminal of the builder hits the same one
+func (p *Pool) DB(dbName string) *builder {
+	var db executor
+	if p.parent == nil {
+		if picked := p.pick(); picked != nil {
+			db = picked
+		}
 	}
-)
 
-func (p *Pool) DB(dbName string) *builder {
-	_, err := p.db.Exec(fmt.Sprintf("USE `%s`", dbName))
-	if err != nil {
-		p.logger.Error(err, "Failed to switch to database "+dbName)
+	b := newBuilder(db, context.Background(), dbName, p.logger)
+	b.rowAlias = p.rowAlias
+	if p.parent != nil {
+		b.after = p
 	}
+	return b
+}
 
+// * private method
+func newBuilder(db executor, ctx context.Context, dbName string, logger *Logger) *builder {
 	return &builder{
-		db:         p.db,
+		db:         db,
+		ctx:        ctx,
 		dbName:     &dbName,
 		selectList: []string{"*"},
-		logger:     p.logger,
+		logger:     logger,
 	}
 }
 
+func (b *builder) WithContext(ctx context.Context) *builder {
+	if ctx != nil {
+		b.ctx = ctx
+	}
+	return b
+}
+
 func (b *builder) Table(tableName string) *builder {
 	b.table = &tableName
 	return b
 }
 
+// * replaces the select list with its bindings, call before SelectSub / SelectRaw
 func (b *builder) Select(fields ...string) *builder {
 	if len(fields) > 0 {
-		b.selectList = fields
+		b.selectBindings = nil
+		b.selectList = make([]string, len(fields))
+		for i, field := range fields {
+			b.selectList[i] = quoteField(field)
+		}
 	}
 	return b
 }
@@ -75,45 +96,11 @@ func (b *builder) join(joinType, table, first, operator string, second ...string
 		secondField = fmt.Sprintf("`%s`", secondField)
 	}
 
-	joinClause := fmt.Sprintf("%s JOIN `%s` ON %s %s %s", joinType, table, first, operator, secondField)
+	joinClause := fmt.Sprintf("%s JOIN %s ON %s %s %s", joinType, b.qualify(table), first, operator, secondField)
 	b.joinList = append(b.joinList, joinClause)
 	return b
 }
 
-func (b *builder) Where(column string, operator interface{}, value ...interface{}) *builder {
-	var targetValue interface{}
-	var targetOperator string
-
-	if len(value) == 0 {
-		targetValue = operator
-		targetOperator = "="
-	} else {
-		targetOperator = fmt.Sprintf("%v", operator)
-		targetValue = value[0]
-	}
-
-	if targetOperator == "LIKE" {
-		if str, ok := targetValue.(string); ok {
-			targetValue = fmt.Sprintf("%%%s%%", str)
-		}
-	}
-
-	if !strings.Contains(column, "(") && !strings.Contains(column, ".") {
-		column = fmt.Sprintf("`%s`", column)
-	}
-
-	placeholder := "?"
-	if targetOperator == "IN" {
-		placeholder = "(?)"
-	}
-
-	whereClause := fmt.Sprintf("%s %s %s", column, targetOperator, placeholder)
-	b.whereList = append(b.whereList, whereClause)
-	b.bindingList = append(b.bindingList, targetValue)
-
-	return b
-}
-
 func (b *builder) OrderBy(column string, direction ...string) *builder {
 	dir := "ASC"
 	if len(direction) > 0 {
@@ -144,23 +131,100 @@ func (b *builder) Offset(num int) *builder {
 	return b
 }
 
-func (b *builder) Increase(target string, number ...int) *builder {
-	num := 1
-	if len(number) > 0 {
-		num = number[0]
+func (b *builder) Increase(column string, args ...interface{}) *builder {
+	return b.arithmetic(column, "+", args...)
+}
+
+func (b *builder) Decrease(column string, args ...interface{}) *builder {
+	return b.arithmetic(column, "-", args...)
+}
+
+// * private method
+// * amount defaults to 1, int / float / Raw are bound, Guard clamps the result
+func (b *builder) arithmetic(column, operator string, args ...interface{}) *builder {
+	var amount interface{} = 1
+	var guards []Guard
+
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case Guard:
+			guards = append(guards, v)
+		case Expression:
+			amount = v
+		default:
+			switch reflect.ValueOf(arg).Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+				reflect.Float32, reflect.Float64:
+				amount = arg
+			default:
+				b.err = b.logger.Error(nil, fmt.Sprintf("Invalid amount %v on %s", arg, column))
+				return b
+			}
+		}
+	}
+
+	target := quoteColumn(column)
+	amountSQL, amountBindings := placeholder(amount)
+	if _, ok := amount.(Expression); ok {
+		amountSQL = "(" + amountSQL + ")"
+	}
+	expression := fmt.Sprintf("%s %s %s", target, operator, amountSQL)
+	bindings := append([]interface{}{}, amountBindings...)
+
+	// * floor of a subtraction goes first, so it wraps the bare column
+	if operator == "-" {
+		sort.SliceStable(guards, func(i, j int) bool {
+			return guards[i].function == "GREATEST" && guards[j].function != "GREATEST"
+		})
+	}
+
+	for i, guard := range guards {
+		guardSQL, guardBindings := placeholder(guard.value)
+
+		// * compare before subtracting, UNSIGNED column fails with 1690 on a value below 0
+		if i == 0 && operator == "-" && guard.function == "GREATEST" {
+			expression = fmt.Sprintf("IF(%s >= %s + %s, %s, %s)", target, guardSQL, amountSQL, expression, guardSQL)
+			bindings = append(append(append(append([]interface{}{}, guardBindings...), amountBindings...), bindings...), guardBindings...)
+			continue
+		}
+
+		expression = fmt.Sprintf("%s(%s, %s)", guard.function, expression, guardSQL)
+		bindings = append(bindings, guardBindings...)
 	}
 
-	setClause := fmt.Sprintf("%s = %s + %d", target, target, num)
-	b.setList = append(b.setList, setClause)
+	b.setList = append(b.setList, fmt.Sprintf("%s = %s", target, expression))
+	b.setBindings = append(b.setBindings, bindings...)
 	return b
 }
 
 // * private method
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
+// * numeric literal and field with table or function are kept as is
+func quoteField(field string) string {
+	switch {
+	case field == "*", strings.Trim(field, "0123456789") == "":
+		return field
+	case strings.ContainsAny(field, ".()"):
+		return field
+	default:
+		return fmt.Sprintf("`%s`", field)
+	}
+}
+
+// * private method
+func (b *builder) tableName() string {
+	return b.qualify(*b.table)
+}
+
+// * private method
+// * table already written as db.table keeps its own database
+func (b *builder) qualify(table string) string {
+	if strings.Contains(table, ".") {
+		return "`" + strings.Join(strings.SplitN(table, ".", 2), "`.`") + "`"
+	}
+
+	if b.dbName == nil || *b.dbName == "" {
+		return fmt.Sprintf("`%s`", table)
 	}
-	return false
+	return fmt.Sprintf("`%s`.`%s`", *b.dbName, table)
 }
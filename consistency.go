@@ -0,0 +1,152 @@
+package goMysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// * GTID is empty when gtid_mode is off or on MariaDB, At is used as fallback
+type Token struct {
+	GTID string    `json:"gtid,omitempty"`
+	At   time.Time `json:"at"`
+}
+
+func (t Token) IsZero() bool {
+	return t.GTID == "" && t.At.IsZero()
+}
+
+// * executed GTID set of the primary, call after the write has committed
+// * writes never return a token by themselves, use this or Track on the write builder
+func (p *Pool) Token(ctx context.Context) Token {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	db := p.pickContext(ctx)
+	if db == nil {
+		return Token{At: time.Now()}
+	}
+	return readToken(ctx, db)
+}
+
+// * opt-in, token is filled after each successful write of the builder
+// * inside Pool.Transaction the GTID is filled after commit
+func (b *builder) Track(token *Token) *builder {
+	b.track = token
+	return b
+}
+
+// * reads through the returned pool are at least as fresh as token
+// * the replica wait runs on the statement ctx, not when the builder is created
+func (p *Pool) After(token Token) *Pool {
+	return &Pool{
+		parent:   p,
+		token:    token,
+		retry:    p.retry,
+		rowAlias: p.rowAlias,
+		logger:   p.logger,
+	}
+}
+
+// * private method
+// * pool returned by After waits on ctx for the replica to catch up
+func (p *Pool) pickContext(ctx context.Context) *sql.DB {
+	if p.parent != nil {
+		return p.parent.pickAfter(ctx, p.token)
+	}
+	return p.pick()
+}
+
+// * private method
+// * replica of a builder from After is picked on its first statement, so WithContext can cancel the wait
+func (b *builder) resolve() {
+	if b.after == nil {
+		return
+	}
+
+	if db := b.after.pickContext(b.ctx); db != nil {
+		b.db = db
+	}
+	b.after = nil
+}
+
+// * private method
+// * inside a transaction the GTID is not assigned before commit, Pool.Transaction fills it after
+func (b *builder) trackToken() {
+	if b.track == nil {
+		return
+	}
+
+	if db, ok := b.db.(*sql.DB); ok {
+		*b.track = readToken(b.ctx, db)
+		return
+	}
+
+	*b.track = Token{At: time.Now()}
+	addToken(b.txTokens, b.track)
+}
+
+// * private method
+func addToken(tokens *[]*Token, token *Token) {
+	if tokens == nil {
+		return
+	}
+
+	for _, tracked := range *tokens {
+		if tracked == token {
+			return
+		}
+	}
+	*tokens = append(*tokens, token)
+}
+
+// * private method
+func readToken(ctx context.Context, db *sql.DB) Token {
+	token := Token{At: time.Now()}
+
+	var gtid sql.NullString
+	if err := db.QueryRowContext(ctx, "SELECT @@GLOBAL.gtid_executed").Scan(&gtid); err == nil {
+		token.GTID = gtid.String
+	}
+	return token
+}
+
+// * private method
+// * wait on a replica for the GTID set, or stick to primary within the window
+func (p *Pool) pickAfter(ctx context.Context, token Token) *sql.DB {
+	if len(p.replicas) == 0 || token.IsZero() {
+		return p.pick()
+	}
+
+	if token.GTID == "" {
+		if time.Since(token.At) < p.consistency.Window {
+			return p.fallback
+		}
+		return p.pick()
+	}
+
+	db := p.pick()
+	if db == p.fallback {
+		return db
+	}
+
+	wait := p.consistency.Wait
+	ctx, cancel := context.WithTimeout(ctx, wait+time.Second)
+	defer cancel()
+
+	// * 0 when applied, 1 on timeout
+	var result sql.NullInt64
+	err := db.QueryRowContext(ctx, "SELECT WAIT_FOR_EXECUTED_GTID_SET(?, ?)", token.GTID, wait.Seconds()).Scan(&result)
+	if err == nil && result.Valid && result.Int64 == 0 {
+		return db
+	}
+
+	if err != nil {
+		p.logger.Debug(fmt.Sprintf("Wait for GTID failed, fall back to write pool: %v", err))
+	} else {
+		p.logger.Debug(fmt.Sprintf("Replica did not catch up in %s, fall back to write pool", wait))
+	}
+	return p.fallback
+}
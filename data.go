@@ -0,0 +1,55 @@
+package goMysql
+
+import (
+	"reflect"
+	"sort"
+)
+
+// * ordered column / value, SQL is generated in the given order
+type Pair struct {
+	Column string
+	Value  interface{}
+}
+
+// * private method
+// * map and struct are sorted by column, so SQL text stays the same between calls
+func (b *builder) normalizeData(data interface{}) ([]string, []interface{}, error) {
+	switch v := data.(type) {
+	case []Pair:
+		columns := make([]string, len(v))
+		values := make([]interface{}, len(v))
+		for i, pair := range v {
+			columns[i] = pair.Column
+			values[i] = pair.Value
+		}
+		return columns, values, nil
+	case map[string]interface{}:
+		columns, values := sortedColumns(v)
+		return columns, values, nil
+	case nil:
+		return nil, nil, b.logger.Error(nil, "Data is required")
+	}
+
+	row, err := b.structToMap(reflect.ValueOf(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns, values := sortedColumns(row)
+	return columns, values, nil
+}
+
+// * private method
+func sortedColumns(data map[string]interface{}) ([]string, []interface{}) {
+	columns := make([]string, 0, len(data))
+	for column := range data {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = data[column]
+	}
+	return columns, values
+}
@@ -0,0 +1,48 @@
+package goMysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+func (b *builder) Delete(force ...bool) (sql.Result, error) {
+	if b.table == nil {
+		return nil, b.logger.Error(nil, "Table is required")
+	}
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if len(b.whereList) == 0 && (len(force) == 0 || !force[0]) {
+		return nil, b.logger.Error(nil, "Delete without WHERE is not allowed, use Delete(true) to force")
+	}
+
+	if b.offset != nil {
+		return nil, b.logger.Error(nil, "OFFSET is not supported in DELETE")
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s", b.tableName())
+
+	if len(b.joinList) > 0 {
+		if len(b.orderList) > 0 || b.limit != nil {
+			return nil, b.logger.Error(nil, "ORDER BY and LIMIT are not supported in multi-table DELETE")
+		}
+		query = fmt.Sprintf("DELETE %s FROM %s %s", b.tableName(), b.tableName(), strings.Join(b.joinList, " "))
+	}
+
+	if len(b.whereList) > 0 {
+		query += " WHERE " + b.whereSQL()
+	}
+
+	if len(b.orderList) > 0 {
+		query += " ORDER BY " + strings.Join(b.orderList, ", ")
+	}
+
+	if b.limit != nil {
+		query += fmt.Sprintf(" LIMIT %d", *b.limit)
+	}
+
+	return b.exec(query, append(b.bindingList, b.orderBindings...)...)
+}
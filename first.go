@@ -0,0 +1,133 @@
+package goMysql
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func (b *builder) First(dest ...interface{}) error {
+	one := 1
+	b.limit = &one
+
+	rows, err := b.Get()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return ErrNoRows
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+
+	return rows.Err()
+}
+
+// * typed by column: int64, float64, string, time.Time, json.RawMessage
+func (b *builder) Value(column string) (interface{}, error) {
+	one := 1
+	b.limit = &one
+	b.withTotal = false
+	b.selectList = []string{quoteField(column)}
+	b.selectBindings = nil
+
+	list, err := b.values()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, ErrNoRows
+	}
+
+	return list[0][0], nil
+}
+
+func (b *builder) Pluck(column string) ([]interface{}, error) {
+	b.withTotal = false
+	b.selectList = []string{quoteField(column)}
+	b.selectBindings = nil
+
+	list, err := b.values()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, len(list))
+	for i, row := range list {
+		result[i] = row[0]
+	}
+	return result, nil
+}
+
+// * keys are formatted as string, later rows overwrite duplicated keys
+func (b *builder) PluckMap(keyColumn, valueColumn string) (map[string]interface{}, error) {
+	b.withTotal = false
+	b.selectList = []string{quoteField(keyColumn), quoteField(valueColumn)}
+	b.selectBindings = nil
+
+	list, err := b.values()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(list))
+	for _, row := range list {
+		key, ok := row[0].(string)
+		if !ok {
+			key = fmt.Sprintf("%v", row[0])
+		}
+		result[key] = row[1]
+	}
+	return result, nil
+}
+
+// * private method
+func (b *builder) values() ([][]interface{}, error) {
+	rows, err := b.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanValues(rows)
+}
+
+// * private method
+// * rows by column index, avoids name collision such as users.id and profiles.id
+func scanValues(rows *sql.Rows) ([][]interface{}, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	list := [][]interface{}{}
+	for rows.Next() {
+		values := make([]interface{}, len(columnTypes))
+		dest := make([]interface{}, len(columnTypes))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		for i, columnType := range columnTypes {
+			values[i] = convertValue(values[i], columnType.DatabaseTypeName())
+		}
+		list = append(list, values)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
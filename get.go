@@ -7,32 +7,55 @@ import (
 )
 
 func (b *builder) Get() (*sql.Rows, error) {
-	if b.table == nil {
-		return nil, b.logger.Error(nil, "Table is required")
+	query, bindings, err := b.toSQL()
+	if err != nil {
+		return nil, err
 	}
 
-	fieldNames := make([]string, len(b.selectList))
-	for i, field := range b.selectList {
-		switch {
-		case field == "*":
-			fieldNames[i] = "*"
-		case strings.ContainsAny(field, ".()"):
-			fieldNames[i] = field
-		default:
-			fieldNames[i] = fmt.Sprintf("`%s`", field)
-		}
+	return b.query(query, bindings...)
+}
+
+// * private method
+func (b *builder) toSQL() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	if b.table == nil && b.fromClause == nil {
+		return "", nil, b.logger.Error(nil, "Table is required")
 	}
 
-	query := fmt.Sprintf("SELECT %s FROM `%s`", strings.Join(fieldNames, ", "), *b.table)
+	var from string
+	if b.fromClause != nil {
+		from = *b.fromClause
+	} else {
+		from = b.tableName()
+	}
+
+	selectKeyword := "SELECT"
+	if b.distinct {
+		selectKeyword = "SELECT DISTINCT"
+	}
+
+	query := fmt.Sprintf("%s %s FROM %s", selectKeyword, strings.Join(b.selectList, ", "), from)
 
 	if len(b.joinList) > 0 {
 		query += " " + strings.Join(b.joinList, " ")
 	}
 
 	if len(b.whereList) > 0 {
-		query += " WHERE " + strings.Join(b.whereList, " AND ")
+		query += " WHERE " + b.whereSQL()
+	}
+
+	if len(b.groupList) > 0 {
+		query += " GROUP BY " + strings.Join(b.groupList, ", ")
 	}
 
+	if len(b.havingList) > 0 {
+		query += " HAVING " + joinConditions(b.havingList)
+	}
+
+	// * wraps the grouped result, so total counts groups instead of rows
 	if b.withTotal {
 		query = fmt.Sprintf("SELECT COUNT(*) OVER() AS total, data.* FROM (%s) AS data", query)
 	}
@@ -49,5 +72,12 @@ func (b *builder) Get() (*sql.Rows, error) {
 		query += fmt.Sprintf(" OFFSET %d", *b.offset)
 	}
 
-	return b.query(query, b.bindingList...)
+	bindings := make([]interface{}, 0, len(b.selectBindings)+len(b.fromBindings)+len(b.bindingList)+len(b.havingBindings)+len(b.orderBindings))
+	bindings = append(bindings, b.selectBindings...)
+	bindings = append(bindings, b.fromBindings...)
+	bindings = append(bindings, b.bindingList...)
+	bindings = append(bindings, b.havingBindings...)
+	bindings = append(bindings, b.orderBindings...)
+
+	return query, bindings, nil
 }
@@ -0,0 +1,58 @@
+package goMysql
+
+func (b *builder) Distinct() *builder {
+	b.distinct = true
+	return b
+}
+
+func (b *builder) GroupBy(columns ...string) *builder {
+	for _, column := range columns {
+		b.groupList = append(b.groupList, quoteColumn(column))
+	}
+	return b
+}
+
+func (b *builder) Having(column string, operator interface{}, value ...interface{}) *builder {
+	return b.having("AND", column, operator, value...)
+}
+
+func (b *builder) OrHaving(column string, operator interface{}, value ...interface{}) *builder {
+	return b.having("OR", column, operator, value...)
+}
+
+func (b *builder) HavingRaw(clause string, bindings ...interface{}) *builder {
+	return b.addHaving("AND", clause, bindings...)
+}
+
+func (b *builder) OrHavingRaw(clause string, bindings ...interface{}) *builder {
+	return b.addHaving("OR", clause, bindings...)
+}
+
+// * private method
+// * reuses where() so IN, BETWEEN, NULL and subquery work the same in HAVING
+func (b *builder) having(boolean, column string, operator interface{}, value ...interface{}) *builder {
+	condition := &builder{
+		db:     b.db,
+		ctx:    b.ctx,
+		dbName: b.dbName,
+		logger: b.logger,
+	}
+	condition.where("AND", column, operator, value...)
+
+	if condition.err != nil {
+		b.err = condition.err
+		return b
+	}
+
+	return b.addHaving(boolean, condition.whereSQL(), condition.bindingList...)
+}
+
+// * private method
+func (b *builder) addHaving(boolean, clause string, bindings ...interface{}) *builder {
+	b.havingList = append(b.havingList, whereClause{
+		boolean: boolean,
+		clause:  clause,
+	})
+	b.havingBindings = append(b.havingBindings, bindings...)
+	return b
+}
@@ -0,0 +1,209 @@
+package goMysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// * empty on write pool
+func (p *Pool) Health() []ReplicaHealth {
+	list := make([]ReplicaHealth, len(p.replicas))
+	for i, r := range p.replicas {
+		r.mu.Lock()
+		list[i] = ReplicaHealth{
+			Name:      r.name,
+			Healthy:   r.healthy.Load(),
+			Lagging:   r.lagging.Load(),
+			Lag:       r.lag,
+			CheckedAt: r.checkedAt,
+		}
+		if r.err != nil {
+			list[i].Error = r.err.Error()
+		}
+		r.mu.Unlock()
+	}
+	return list
+}
+
+// * private method
+func (p *Pool) startHealthCheck() {
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+
+	go func() {
+		defer close(p.done)
+
+		ticker := time.NewTicker(p.health.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.checkReplicas()
+			}
+		}
+	}()
+}
+
+// * private method
+func (p *Pool) stopHealthCheck() {
+	if p.stop == nil {
+		return
+	}
+
+	close(p.stop)
+	<-p.done
+	p.stop = nil
+}
+
+// * private method
+// * replicas are pinged in parallel, so a hanging one does not delay the others
+func (p *Pool) checkReplicas() {
+	var wg sync.WaitGroup
+	healthChanged := make([]bool, len(p.replicas))
+	lagChanged := make([]bool, len(p.replicas))
+
+	for i, r := range p.replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), p.health.Timeout)
+			defer cancel()
+
+			err := r.db.PingContext(ctx)
+			healthChanged[i] = r.setHealth(err)
+			if err != nil || p.health.MaxLag <= 0 {
+				return
+			}
+
+			lag, err := p.replicaLag(ctx, r.db)
+			lagChanged[i] = r.setLag(lag, lag > p.health.MaxLag, err)
+		}()
+	}
+	wg.Wait()
+
+	anyChanged := false
+	healthy, fresh := 0, 0
+	for i, r := range p.replicas {
+		if r.healthy.Load() {
+			healthy++
+			if !r.lagging.Load() {
+				fresh++
+			}
+		}
+
+		if healthChanged[i] {
+			anyChanged = true
+			if r.healthy.Load() {
+				p.logger.Info(fmt.Sprintf("Read replica %s recovered, back in rotation", r.name))
+			} else {
+				p.logger.Warn(fmt.Sprintf("Read replica %s is down, removed from rotation", r.name), r.err.Error())
+			}
+		}
+
+		if lagChanged[i] {
+			anyChanged = true
+			if r.lagging.Load() {
+				p.logger.Warn(fmt.Sprintf("Read replica %s lag %s exceeds %s, removed from rotation", r.name, r.lag, p.health.MaxLag))
+			} else {
+				p.logger.Info(fmt.Sprintf("Read replica %s lag %s is within %s, back in rotation", r.name, r.lag, p.health.MaxLag))
+			}
+		}
+	}
+
+	switch {
+	case !anyChanged || fresh > 0:
+	case healthy == 0:
+		p.logger.Warn("No healthy read replica, fall back to write pool")
+	default:
+		p.logger.Warn(fmt.Sprintf("Every read replica exceeds max lag %s, fall back to write pool", p.health.MaxLag))
+	}
+}
+
+// * private method
+func (p *Pool) replicaLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	if p.health.Heartbeat != "" {
+		return heartbeatLag(ctx, db, p.health.Heartbeat)
+	}
+	return statusLag(ctx, db)
+}
+
+// * private method
+// * the largest lag is used on multi-source replication, no row means not a replica
+func statusLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	rows, err := db.QueryContext(ctx, "SHOW REPLICA STATUS")
+	if err != nil {
+		// * before MySQL 8.0.22
+		rows, err = db.QueryContext(ctx, "SHOW SLAVE STATUS")
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	index := -1
+	for i, column := range columns {
+		if column == "Seconds_Behind_Source" || column == "Seconds_Behind_Master" {
+			index = i
+		}
+	}
+	if index < 0 {
+		return 0, fmt.Errorf("Seconds_Behind_Source is not found in replica status")
+	}
+
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	var lag time.Duration
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+
+		// * NULL when replication thread is stopped
+		if values[index] == nil {
+			return 0, fmt.Errorf("Replication is not running")
+		}
+
+		seconds, err := strconv.ParseInt(string(values[index]), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		lag = max(lag, time.Duration(seconds)*time.Second)
+	}
+
+	return lag, rows.Err()
+}
+
+// * private method
+func heartbeatLag(ctx context.Context, db *sql.DB, table string) (time.Duration, error) {
+	table = "`" + strings.Join(strings.SplitN(table, ".", 2), "`.`") + "`"
+
+	var micro sql.NullInt64
+	query := fmt.Sprintf("SELECT TIMESTAMPDIFF(MICROSECOND, MAX(`ts`), UTC_TIMESTAMP(6)) FROM %s", table)
+	if err := db.QueryRowContext(ctx, query).Scan(&micro); err != nil {
+		return 0, err
+	}
+
+	if !micro.Valid {
+		return 0, fmt.Errorf("Heartbeat table %s is empty", table)
+	}
+
+	return max(time.Duration(micro.Int64)*time.Microsecond, 0), nil
+}
@@ -1,27 +1,78 @@
 package goMysql
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
 )
 
-func (b *builder) Insert(data map[string]interface{}) (int64, error) {
+func (b *builder) Insert(data interface{}) (int64, error) {
+	return b.insert("INSERT", data)
+}
+
+// * duplicated key rows are skipped, last insert id is 0 when skipped
+func (b *builder) InsertIgnore(data interface{}) (int64, error) {
+	return b.insert("INSERT IGNORE", data)
+}
+
+// * duplicated key rows are deleted then inserted
+func (b *builder) Replace(data interface{}) (int64, error) {
+	return b.insert("REPLACE", data)
+}
+
+func (b *builder) InsertUsing(columns []string, source *Builder) (sql.Result, error) {
+	if b.table == nil {
+		return nil, b.logger.Error(nil, "Table is required")
+	}
+
+	if source == nil {
+		return nil, b.logger.Error(nil, "Source builder is required")
+	}
+
+	query, bindings, err := source.toSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	columnNames := make([]string, len(columns))
+	for i, column := range columns {
+		columnNames[i] = fmt.Sprintf("`%s`", column)
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s (%s) %s",
+		b.tableName(),
+		strings.Join(columnNames, ", "),
+		query,
+	)
+
+	return b.exec(query, bindings...)
+}
+
+// * private method
+func (b *builder) insert(verb string, data interface{}) (int64, error) {
 	if b.table == nil {
 		return 0, b.logger.Error(nil, "Table is required")
 	}
 
-	columns := make([]string, 0, len(data))
-	values := make([]interface{}, 0, len(data))
-	placeholders := make([]string, 0, len(data))
+	columnList, columnValues, err := b.normalizeData(data)
+	if err != nil {
+		return 0, err
+	}
+
+	columns := make([]string, len(columnList))
+	placeholders := make([]string, len(columnList))
+	values := []interface{}{}
 
-	for column, value := range data {
-		columns = append(columns, fmt.Sprintf("`%s`", column))
-		values = append(values, value)
-		placeholders = append(placeholders, "?")
+	for i, column := range columnList {
+		valueSQL, bindings := placeholder(columnValues[i])
+		columns[i] = fmt.Sprintf("`%s`", column)
+		placeholders[i] = valueSQL
+		values = append(values, bindings...)
 	}
 
-	query := fmt.Sprintf("INSERT INTO `%s` (%s) VALUES (%s)",
-		*b.table,
+	query := fmt.Sprintf("%s INTO %s (%s) VALUES (%s)",
+		verb,
+		b.tableName(),
 		strings.Join(columns, ", "),
 		strings.Join(placeholders, ", "),
 	)
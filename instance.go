@@ -1,10 +1,13 @@
 package goMysql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -26,115 +29,200 @@ func New(c Config) (*PoolList, error) {
 		logger: logger,
 	}
 
-	readConfig := c.Read
-
-	if readConfig.Host == "" {
-		readConfig.Host = "localhost"
+	readConfigs := c.Reads
+	if len(readConfigs) == 0 {
+		readConfigs = []*DBConfig{c.Read}
 	}
 
-	if readConfig.Port == 0 {
-		readConfig.Port = 3306
+	pool.Read = &Pool{
+		strategy:    validStrategy(c.Strategy),
+		health:      validHealthConfig(c),
+		consistency: validConsistencyConfig(c),
+		logger:      logger,
 	}
+	aliasChecked := false
+	fresh := 0
+	for _, readConfig := range readConfigs {
+		if readConfig == nil {
+			pool.Close()
+			return nil, logger.Error(nil, "Read config is required")
+		}
 
-	if readConfig.User == "" {
-		readConfig.User = "root"
-	}
+		read, err := openDB(readConfig)
+		if err != nil {
+			pool.Close()
+			return nil, logger.Error(err, "Failed to create read pool")
+		}
 
-	if readConfig.Password == "" {
-		readConfig.Password = ""
-	}
+		r := &replica{
+			db:     read,
+			name:   fmt.Sprintf("%s:%d", readConfig.Host, readConfig.Port),
+			weight: readConfig.Weight,
+		}
+		pool.Read.replicas = append(pool.Read.replicas, r)
 
-	if readConfig.Charset == "" {
-		readConfig.Charset = "utf8mb4"
-	}
+		// * a replica down at startup stays out of rotation until the health check restores it
+		if err := read.Ping(); err != nil {
+			r.setHealth(err)
+			logger.Warn(fmt.Sprintf("Read replica %s is down, removed from rotation", r.name), err.Error())
+			continue
+		}
+		r.setHealth(nil)
 
-	if readConfig.Connection == 0 {
-		readConfig.Connection = 4
-	}
+		if pool.Read.health.MaxLag > 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), pool.Read.health.Timeout)
+			lag, err := pool.Read.replicaLag(ctx, read)
+			cancel()
 
-	read, err := sql.Open("mysql",
-		fmt.Sprintf(
-			"%s:%s@tcp(%s:%d)/?charset=%s&parseTime=true",
-			readConfig.User,
-			readConfig.Password,
-			readConfig.Host,
-			readConfig.Port,
-			readConfig.Charset,
-		),
-	)
-	if err != nil {
-		return nil, logger.Error(err, "Failed to create read pool")
-	}
+			if r.setLag(lag, lag > pool.Read.health.MaxLag, err); r.lagging.Load() {
+				logger.Warn(fmt.Sprintf("Read replica %s lag %s exceeds %s, removed from rotation", r.name, lag, pool.Read.health.MaxLag))
+			}
+		}
 
-	read.SetMaxOpenConns(readConfig.Connection)
-	read.SetMaxIdleConns(readConfig.Connection / 2)
-	read.SetConnMaxLifetime(time.Hour)
+		if !r.lagging.Load() {
+			fresh++
+		}
 
-	if err := read.Ping(); err != nil {
-		return nil, logger.Error(err, "Failed to connect read pool")
+		if !aliasChecked {
+			pool.Read.rowAlias = supportsRowAlias(read)
+			aliasChecked = true
+		}
 	}
 
-	pool.Read = &Pool{db: read}
-
 	writeConfig := c.Write
 	if writeConfig == nil {
-		writeConfig = readConfig
-	}
-
-	writeDB, err := sql.Open(
-		"mysql",
-		fmt.Sprintf(
-			"%s:%s@tcp(%s:%d)/?charset=%s&parseTime=true",
-			writeConfig.User,
-			writeConfig.Password,
-			writeConfig.Host,
-			writeConfig.Port,
-			writeConfig.Charset,
-		),
-	)
+		writeConfig = readConfigs[0]
+	}
+
+	writeDB, err := openDB(writeConfig)
 	if err != nil {
+		pool.Close()
 		return nil, logger.Error(err, "Failed to create write pool")
 	}
 
-	writeDB.SetMaxOpenConns(writeConfig.Connection)
-	writeDB.SetMaxIdleConns(writeConfig.Connection / 2)
-	writeDB.SetConnMaxLifetime(time.Hour)
+	pool.Write = &Pool{db: writeDB}
 
 	if err := writeDB.Ping(); err != nil {
+		pool.Close()
 		return nil, logger.Error(err, "Failed to connect write pool")
 	}
 
-	pool.Write = &Pool{db: writeDB}
+	pool.Write.rowAlias = supportsRowAlias(writeDB)
+
+	// * health check only logs on change, state at startup is reported here
+	switch {
+	case !aliasChecked:
+		logger.Warn("No healthy read replica, fall back to write pool")
+		pool.Read.rowAlias = pool.Write.rowAlias
+	case fresh == 0:
+		logger.Warn(fmt.Sprintf("Every read replica exceeds max lag %s, fall back to write pool", pool.Read.health.MaxLag))
+	}
 
 	pool.listenShutdownSignal()
 	pool.Write.logger = logger
-	pool.Read.logger = logger
+	pool.Read.fallback = writeDB
+	pool.Write.retry = validRetryConfig(c)
+	pool.Read.retry = pool.Write.retry
+	pool.Read.startHealthCheck()
 	return pool, nil
 }
 
 func (p *PoolList) Close() error {
 	var readErr, writeErr error
 
-	if p.Read != nil && p.Read.db != nil {
-		readErr = p.Read.db.Close()
+	if p.Read != nil {
+		p.Read.stopHealthCheck()
+		for _, r := range p.Read.replicas {
+			if err := closeDB(r.db); err != nil && readErr == nil {
+				readErr = err
+			}
+		}
 		p.Read = nil
 	}
 
 	if p.Write != nil && p.Write.db != nil {
-		writeErr = p.Write.db.Close()
+		writeErr = closeDB(p.Write.db)
 		p.Write = nil
 	}
 
 	if readErr != nil {
-		return p.Write.logger.Error(readErr, "Failed to close read pool")
+		return p.logger.Error(readErr, "Failed to close read pool")
 	}
 	if writeErr != nil {
-		return p.Write.logger.Error(writeErr, "Failed to close write pool")
+		return p.logger.Error(writeErr, "Failed to close write pool")
 	}
 
 	return nil
 }
 
+// * private method
+// * applies defaults on config, each endpoint has its own connection count
+// * connection is not verified here, caller decides whether ping failure is fatal
+func openDB(c *DBConfig) (*sql.DB, error) {
+	if c.Host == "" {
+		c.Host = "localhost"
+	}
+
+	if c.Port == 0 {
+		c.Port = 3306
+	}
+
+	if c.User == "" {
+		c.User = "root"
+	}
+
+	if c.Charset == "" {
+		c.Charset = "utf8mb4"
+	}
+
+	if c.Connection == 0 {
+		c.Connection = 4
+	}
+
+	if c.Weight <= 0 {
+		c.Weight = 1
+	}
+
+	dsn := fmt.Sprintf(
+		"%s:%s@tcp(%s:%d)/?charset=%s&parseTime=true",
+		c.User,
+		c.Password,
+		c.Host,
+		c.Port,
+		c.Charset,
+	)
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(c.Connection)
+	db.SetMaxIdleConns(c.Connection / 2)
+	db.SetConnMaxLifetime(time.Hour)
+
+	// * separate from db, so KILL QUERY does not wait behind the busy connections it cancels
+	killer, err := sql.Open("mysql", dsn)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	killer.SetMaxOpenConns(killPoolSize)
+	killer.SetMaxIdleConns(0)
+	killPools.Store(db, killer)
+
+	return db, nil
+}
+
+// * private method
+func closeDB(db *sql.DB) error {
+	if v, ok := killPools.LoadAndDelete(db); ok {
+		_ = v.(*sql.DB).Close()
+	}
+	return db.Close()
+}
+
 func (p *PoolList) listenShutdownSignal() {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -146,6 +234,31 @@ func (p *PoolList) listenShutdownSignal() {
 	}()
 }
 
+// * row alias (INSERT ... AS new) is supported since MySQL 8.0.19, not by MariaDB
+func supportsRowAlias(db *sql.DB) bool {
+	var version string
+	if err := db.QueryRow("SELECT VERSION()").Scan(&version); err != nil {
+		return false
+	}
+
+	if strings.Contains(strings.ToLower(version), "mariadb") {
+		return false
+	}
+
+	parts := strings.SplitN(strings.SplitN(version, "-", 2)[0], ".", 3)
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		numbers[i], _ = strconv.Atoi(part)
+	}
+
+	for i, min := range []int{8, 0, 19} {
+		if numbers[i] != min {
+			return numbers[i] > min
+		}
+	}
+	return true
+}
+
 func validLoggerConfig(c Config) *Log {
 	if c.Log == nil {
 		c.Log = &Log{
@@ -165,3 +278,57 @@ func validLoggerConfig(c Config) *Log {
 	}
 	return c.Log
 }
+
+func validStrategy(strategy Strategy) Strategy {
+	switch strategy {
+	case StrategyRandom, StrategyWeighted, StrategyLeastInFlight:
+		return strategy
+	default:
+		return StrategyRoundRobin
+	}
+}
+
+func validHealthConfig(c Config) *HealthConfig {
+	if c.Health == nil {
+		c.Health = &HealthConfig{}
+	}
+	if c.Health.Interval <= 0 {
+		c.Health.Interval = defaultHealthInterval
+	}
+	if c.Health.Timeout <= 0 {
+		c.Health.Timeout = defaultHealthTimeout
+	}
+	return c.Health
+}
+
+func validConsistencyConfig(c Config) *ConsistencyConfig {
+	if c.Consistency == nil {
+		c.Consistency = &ConsistencyConfig{}
+	}
+	if c.Consistency.Wait <= 0 {
+		c.Consistency.Wait = defaultConsistencyWait
+	}
+	if c.Consistency.Window <= 0 {
+		c.Consistency.Window = defaultConsistencyWindow
+	}
+	return c.Consistency
+}
+
+func validRetryConfig(c Config) *RetryConfig {
+	if c.Retry == nil {
+		c.Retry = &RetryConfig{}
+	}
+	if c.Retry.MaxAttempts <= 0 {
+		c.Retry.MaxAttempts = defaultRetryAttempts
+	}
+	if c.Retry.BaseDelay <= 0 {
+		c.Retry.BaseDelay = defaultRetryBaseDelay
+	}
+	if c.Retry.MaxDelay <= 0 {
+		c.Retry.MaxDelay = defaultRetryMaxDelay
+	}
+	if len(c.Retry.Codes) == 0 {
+		c.Retry.Codes = defaultRetryCodes
+	}
+	return c.Retry
+}
@@ -0,0 +1,123 @@
+package goMysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (b *builder) GetMaps() ([]map[string]interface{}, error) {
+	rows, err := b.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMaps(rows)
+}
+
+func (b *builder) FirstMap() (map[string]interface{}, error) {
+	one := 1
+	b.limit = &one
+
+	list, err := b.GetMaps()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, ErrNoRows
+	}
+
+	return list[0], nil
+}
+
+func (p *Pool) QueryMaps(query string, params ...interface{}) ([]map[string]interface{}, error) {
+	return p.QueryMapsContext(context.Background(), query, params...)
+}
+
+func (p *Pool) QueryMapsContext(ctx context.Context, query string, params ...interface{}) ([]map[string]interface{}, error) {
+	rows, err := p.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMaps(rows)
+}
+
+// * private method
+func scanMaps(rows *sql.Rows) ([]map[string]interface{}, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := scanValues(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]map[string]interface{}, len(values))
+	for i, row := range values {
+		list[i] = make(map[string]interface{}, len(columns))
+		for j, column := range columns {
+			list[i][column] = row[j]
+		}
+	}
+
+	return list, nil
+}
+
+// * private method
+// * text protocol returns []byte for every type, convert by column type
+func convertValue(value interface{}, typeName string) interface{} {
+	if v, ok := value.(float32); ok {
+		return float64(v)
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return value
+	}
+
+	str := string(bytes)
+	unsigned := strings.HasPrefix(typeName, "UNSIGNED ")
+	typeName = strings.TrimPrefix(typeName, "UNSIGNED ")
+
+	switch typeName {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "BIGINT", "YEAR":
+		if unsigned {
+			if v, err := strconv.ParseUint(str, 10, 64); err == nil {
+				if v <= 1<<63-1 {
+					return int64(v)
+				}
+				return v
+			}
+		} else if v, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return v
+		}
+	case "FLOAT", "DOUBLE":
+		if v, err := strconv.ParseFloat(str, 64); err == nil {
+			return v
+		}
+	case "DECIMAL":
+		// * keep as string to avoid losing precision
+		return str
+	case "JSON":
+		return json.RawMessage(bytes)
+	case "DATE", "DATETIME", "TIMESTAMP":
+		for _, layout := range []string{"2006-01-02 15:04:05.999999", "2006-01-02"} {
+			if v, err := time.Parse(layout, str); err == nil {
+				return v
+			}
+		}
+	case "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BINARY", "VARBINARY", "BIT", "GEOMETRY":
+		return bytes
+	}
+
+	return str
+}
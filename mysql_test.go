@@ -1,9 +1,17 @@
 package goMysql
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 var pool *PoolList
@@ -66,13 +74,8 @@ func TestCreateTestTable(t *testing.T) {
 		t.Fatalf("Failed to create test database: %v", err)
 	}
 
-	_, err = pool.Write.Exec("USE test_db")
-	if err != nil {
-		t.Fatalf("Failed to use test database: %v", err)
-	}
-
 	_, err = pool.Write.Exec(`
-        CREATE TABLE IF NOT EXISTS users (
+        CREATE TABLE IF NOT EXISTS test_db.users (
             id INT AUTO_INCREMENT PRIMARY KEY,
             name VARCHAR(100) NOT NULL,
             email VARCHAR(100) UNIQUE NOT NULL,
@@ -87,7 +90,7 @@ func TestCreateTestTable(t *testing.T) {
 	}
 
 	_, err = pool.Write.Exec(`
-        CREATE TABLE IF NOT EXISTS profiles (
+        CREATE TABLE IF NOT EXISTS test_db.profiles (
             id INT AUTO_INCREMENT PRIMARY KEY,
             user_id INT,
             bio TEXT,
@@ -289,7 +292,7 @@ func TestUpsertData(t *testing.T) {
 		"status": "updated",
 	}
 
-	lastID, err := pool.Write.
+	result, err := pool.Write.
 		DB("test_db").
 		Table("users").
 		Upsert(data, updateData)
@@ -298,20 +301,21 @@ func TestUpsertData(t *testing.T) {
 		t.Fatalf("Upsert failed: %v", err)
 	}
 
-	t.Logf("Upserted user with ID: %d", lastID)
+	t.Logf("Upserted user with ID: %d (%s)", result.ID, result.Outcome)
 
 	// 測試更新現有資料
 	data["age"] = 42
-	lastID2, err := pool.Write.
+	result2, err := pool.Write.
 		DB("test_db").
 		Table("users").
+		ReturnID("id").
 		Upsert(data, updateData)
 
 	if err != nil {
 		t.Fatalf("Second upsert failed: %v", err)
 	}
 
-	t.Logf("Second upsert returned ID: %d", lastID2)
+	t.Logf("Second upsert returned ID: %d (%s)", result2.ID, result2.Outcome)
 }
 
 func TestJoinQuery(t *testing.T) {
@@ -461,6 +465,80 @@ func TestIncreaseMethod(t *testing.T) {
 	t.Logf("Increased age for %d users", rowsAffected)
 }
 
+func TestDecreaseWithGuard(t *testing.T) {
+	_, err := pool.Write.
+		DB("test_db").
+		Table("users").
+		Where("email", "john@example.com").
+		Decrease("age", 1000, AtLeast(0)).
+		Update()
+	if err != nil {
+		t.Fatalf("Decrease failed: %v", err)
+	}
+
+	_, err = pool.Write.
+		DB("test_db").
+		Table("users").
+		Where("email", "john@example.com").
+		Increase("age", 150, AtMost(120)).
+		Update()
+	if err != nil {
+		t.Fatalf("Increase with guard failed: %v", err)
+	}
+
+	age, err := pool.Write.
+		DB("test_db").
+		Table("users").
+		Where("email", "john@example.com").
+		Value("age")
+	if err != nil || age != int64(120) {
+		t.Fatalf("Expected age 120, got %v: %v", age, err)
+	}
+
+	// 數值以外的數量不會執行
+	_, err = pool.Write.
+		DB("test_db").
+		Table("users").
+		Where("email", "john@example.com").
+		Increase("age", "1, name = 'x'").
+		Update()
+	if err == nil {
+		t.Fatal("Expected invalid amount error")
+	}
+
+	// UNSIGNED 欄位扣減至下限時不可溢位
+	_, err = pool.Write.Exec("CREATE TABLE IF NOT EXISTS test_db.stock (id INT PRIMARY KEY, quantity INT UNSIGNED NOT NULL)")
+	if err != nil {
+		t.Fatalf("Failed to create stock table: %v", err)
+	}
+	defer pool.Write.Exec("DROP TABLE IF EXISTS test_db.stock")
+
+	if _, err := pool.Write.Exec("REPLACE INTO test_db.stock (id, quantity) VALUES (1, 3)"); err != nil {
+		t.Fatalf("Failed to insert stock: %v", err)
+	}
+
+	for _, step := range []struct{ amount, expected int64 }{{2, 1}, {5, 0}} {
+		_, err = pool.Write.
+			DB("test_db").
+			Table("stock").
+			Where("id", 1).
+			Decrease("quantity", step.amount, AtLeast(0)).
+			Update()
+		if err != nil {
+			t.Fatalf("Decrease on unsigned column failed: %v", err)
+		}
+
+		quantity, err := pool.Write.
+			DB("test_db").
+			Table("stock").
+			Where("id", 1).
+			Value("quantity")
+		if err != nil || quantity != step.expected {
+			t.Fatalf("Expected quantity %d, got %v: %v", step.expected, quantity, err)
+		}
+	}
+}
+
 func TestSlowQueryLogging(t *testing.T) {
 	// 模擬慢查詢
 	rows, err := pool.Read.Query("SELECT SLEEP(0.1), 'slow query test'")
@@ -482,9 +560,1255 @@ func TestSlowQueryLogging(t *testing.T) {
 	t.Log("Slow query test completed (check logs for slow query warning)")
 }
 
-func TestCleanup(t *testing.T) {
-	// 清理測試資料
-	_, err := pool.Write.Exec("DROP TABLE IF EXISTS test_db.profiles")
+func TestQueryContextCancel(t *testing.T) {
+	// 測試逾時後中斷執行中的查詢
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	startTime := time.Now()
+	rows, err := pool.Read.QueryContext(ctx, "SELECT SLEEP(3)")
+	if err == nil {
+		rows.Close()
+	}
+	duration := time.Since(startTime)
+
+	if duration > time.Second {
+		t.Fatalf("Expected query to be aborted, took %s", duration)
+	}
+
+	startTime = time.Now()
+	_, err = pool.Write.
+		DB("test_db").
+		Table("users").
+		WithContext(ctx).
+		Where("email", "john@example.com").
+		Update(map[string]interface{}{"status": "active"})
+	if err == nil {
+		t.Fatal("Expected error with cancelled context")
+	}
+
+	t.Logf("Query aborted after %s: %v", duration, err)
+
+	// 可取消的 context 重複使用連線時不再查詢 CONNECTION_ID
+	liveCtx, liveCancel := context.WithCancel(context.Background())
+	defer liveCancel()
+	for i := 0; i < 3; i++ {
+		if _, err := pool.Write.ExecContext(liveCtx, "SELECT 1"); err != nil {
+			t.Fatalf("Exec with cancellable context failed: %v", err)
+		}
+	}
+
+	cached := 0
+	connIDs.Range(func(_, _ interface{}) bool {
+		cached++
+		return true
+	})
+	if cached == 0 {
+		t.Fatal("Expected connection id to be cached")
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	// 測試交易提交
+	err := pool.Write.Transaction(context.Background(), func(tx *Tx) error {
+		_, err := tx.
+			DB("test_db").
+			Table("users").
+			Insert(map[string]interface{}{
+				"name":  "Tx Commit",
+				"email": "tx_commit@example.com",
+				"age":   20,
+			})
+		if err != nil {
+			return err
+		}
+
+		// 測試巢狀交易回滾至 SAVEPOINT
+		nestedErr := tx.Transaction(func(tx *Tx) error {
+			_, err := tx.
+				DB("test_db").
+				Table("users").
+				Insert(map[string]interface{}{
+					"name":  "Tx Savepoint",
+					"email": "tx_savepoint@example.com",
+					"age":   20,
+				})
+			if err != nil {
+				return err
+			}
+			return errors.New("rollback savepoint")
+		})
+		if nestedErr == nil {
+			t.Fatal("Expected nested transaction error")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction failed: %v", err)
+	}
+
+	// 測試交易回滾
+	err = pool.Write.Transaction(context.Background(), func(tx *Tx) error {
+		_, err := tx.
+			DB("test_db").
+			Table("users").
+			Insert(map[string]interface{}{
+				"name":  "Tx Rollback",
+				"email": "tx_rollback@example.com",
+				"age":   20,
+			})
+		if err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+	if err == nil {
+		t.Fatal("Expected transaction error")
+	}
+
+	var count int
+	err = pool.Write.db.QueryRow(
+		"SELECT COUNT(*) FROM test_db.users WHERE email IN (?, ?, ?)",
+		"tx_commit@example.com", "tx_savepoint@example.com", "tx_rollback@example.com",
+	).Scan(&count)
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+
+	if count != 1 {
+		t.Fatalf("Expected only committed row to exist, got %d", count)
+	}
+
+	t.Log("Transaction commit and rollback successful")
+}
+
+func TestTransactionRetry(t *testing.T) {
+	// 測試鎖等待逾時後重試交易
+	lockTx, err := pool.Write.db.Begin()
+	if err != nil {
+		t.Fatalf("Failed to begin lock transaction: %v", err)
+	}
+	defer lockTx.Rollback()
+
+	_, err = lockTx.Exec("SELECT id FROM test_db.users WHERE email = ? FOR UPDATE", "john@example.com")
+	if err != nil {
+		t.Fatalf("Failed to lock row: %v", err)
+	}
+
+	attempts := 0
+	err = pool.Write.Transaction(context.Background(), func(tx *Tx) error {
+		attempts++
+		if _, err := tx.Exec("SET SESSION innodb_lock_wait_timeout = 1"); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec("UPDATE test_db.users SET age = age WHERE email = ?", "john@example.com")
+		if err != nil && attempts == 1 {
+			// 釋放鎖讓下一次重試成功
+			lockTx.Rollback()
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Transaction retry failed: %v", err)
+	}
+
+	if attempts < 2 {
+		t.Fatalf("Expected transaction to be retried, got %d attempts", attempts)
+	}
+
+	t.Logf("Transaction succeeded after %d attempts", attempts)
+}
+
+func TestDeleteData(t *testing.T) {
+	// 測試未帶 WHERE 的刪除應被拒絕
+	_, err := pool.Write.
+		DB("test_db").
+		Table("profiles").
+		Delete()
+	if err == nil {
+		t.Fatal("Expected delete without WHERE to be refused")
+	}
+
+	_, err = pool.Write.
+		DB("test_db").
+		Table("users").
+		Insert(map[string]interface{}{
+			"name":   "Delete Me",
+			"email":  "delete@example.com",
+			"age":    50,
+			"status": "deleted",
+		})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	result, err := pool.Write.
+		DB("test_db").
+		Table("users").
+		Where("email", "delete@example.com").
+		Limit(1).
+		Delete()
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.Fatalf("Failed to get rows affected: %v", err)
+	}
+
+	if rowsAffected != 1 {
+		t.Fatalf("Expected to delete 1 row, got %d", rowsAffected)
+	}
+
+	t.Logf("Deleted %d rows", rowsAffected)
+}
+
+type testTimestamps struct {
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+type testUser struct {
+	testTimestamps
+	ID     int64         `db:"id"`
+	Name   string        `db:"name"`
+	Email  string        `db:"email"`
+	Age    sql.NullInt64 `db:"age"`
+	Status *string       `db:"status"`
+}
+
+func TestScanIntoStruct(t *testing.T) {
+	var users []testUser
+	err := pool.Read.
+		DB("test_db").
+		Table("users").
+		OrderBy("id", "ASC").
+		GetInto(&users)
+	if err != nil {
+		t.Fatalf("GetInto failed: %v", err)
+	}
+
+	if len(users) == 0 {
+		t.Fatal("Expected to scan users, but got none")
+	}
+
+	var user testUser
+	err = pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("email", "john@example.com").
+		FirstInto(&user)
+	if err != nil {
+		t.Fatalf("FirstInto failed: %v", err)
+	}
+
+	if user.Email != "john@example.com" || user.CreatedAt.IsZero() {
+		t.Fatalf("Unexpected scanned user: %+v", user)
+	}
+
+	err = pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("email", "nobody@example.com").
+		FirstInto(&user)
+	if !errors.Is(err, ErrNoRows) {
+		t.Fatalf("Expected ErrNoRows, got: %v", err)
+	}
+
+	list, err := Find[testUser](pool.Read.
+		DB("test_db").
+		Table("users").
+		Select("id", "name"))
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+
+	// 測試嚴格模式回報未映射欄位
+	_, err = Find[testUser](pool.Read.
+		DB("test_db").
+		Table("users").
+		Select("id", "name").
+		Total().
+		Strict())
+	if err == nil {
+		t.Fatal("Expected strict mode to report unmapped column total")
+	}
+
+	t.Logf("Scanned %d users, found %d users", len(users), len(list))
+}
+
+func TestGetMaps(t *testing.T) {
+	list, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Select("id", "name", "created_at").
+		Where("status", "active").
+		GetMaps()
+	if err != nil {
+		t.Fatalf("GetMaps failed: %v", err)
+	}
+
+	for _, row := range list {
+		if _, ok := row["id"].(int64); !ok {
+			t.Fatalf("Expected id to be int64, got %T", row["id"])
+		}
+		if _, ok := row["name"].(string); !ok {
+			t.Fatalf("Expected name to be string, got %T", row["name"])
+		}
+		if _, ok := row["created_at"].(time.Time); !ok {
+			t.Fatalf("Expected created_at to be time.Time, got %T", row["created_at"])
+		}
+	}
+
+	row, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("email", "john@example.com").
+		FirstMap()
+	if err != nil {
+		t.Fatalf("FirstMap failed: %v", err)
+	}
+
+	// 不帶參數時走文字協定，所有欄位皆為 []byte
+	maps, err := pool.Read.QueryMaps("SELECT COUNT(*) AS total, 1.5 AS ratio FROM test_db.users")
+	if err != nil {
+		t.Fatalf("QueryMaps failed: %v", err)
+	}
+
+	if _, ok := maps[0]["total"].(int64); !ok {
+		t.Fatalf("Expected total to be int64, got %T", maps[0]["total"])
+	}
+
+	t.Logf("Got %d rows, first: %v, aggregate: %v", len(list), row, maps[0])
+}
+
+func TestWhereGroup(t *testing.T) {
+	// status = ? AND (age > ? OR email = ?)
+	var users []testUser
+	err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("status", "active").
+		WhereGroup(func(q *Builder) {
+			q.Where("age", ">", 26).
+				OrWhere("email", "jane@example.com")
+		}).
+		GetInto(&users)
+	if err != nil {
+		t.Fatalf("WhereGroup failed: %v", err)
+	}
+
+	for _, user := range users {
+		if user.Status == nil || *user.Status != "active" {
+			t.Fatalf("Expected only active users, got: %+v", user)
+		}
+	}
+
+	t.Logf("Found %d users with grouped conditions", len(users))
+}
+
+func TestWhereIn(t *testing.T) {
+	emails := []string{"john@example.com", "jane@example.com", "bob@example.com"}
+
+	var users []testUser
+	err := pool.Read.
+		DB("test_db").
+		Table("users").
+		WhereIn("email", emails).
+		GetInto(&users)
+	if err != nil {
+		t.Fatalf("WhereIn failed: %v", err)
+	}
+
+	if len(users) != len(emails) {
+		t.Fatalf("Expected %d users, got %d", len(emails), len(users))
+	}
+
+	// 空切片應回傳空結果
+	err = pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("id", "IN", []int{}).
+		GetInto(&users)
+	if err != nil {
+		t.Fatalf("WhereIn with empty slice failed: %v", err)
+	}
+
+	if len(users) != 0 {
+		t.Fatalf("Expected no users for empty IN, got %d", len(users))
+	}
+
+	err = pool.Read.
+		DB("test_db").
+		Table("users").
+		WhereNotIn("email", emails).
+		GetInto(&users)
+	if err != nil {
+		t.Fatalf("WhereNotIn failed: %v", err)
+	}
+
+	t.Logf("Found %d users not in list", len(users))
+}
+
+func TestWhereNullAndBetween(t *testing.T) {
+	var users []testUser
+	err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("age", nil).
+		GetInto(&users)
+	if err != nil {
+		t.Fatalf("Where nil failed: %v", err)
+	}
+
+	if len(users) != 0 {
+		t.Fatalf("Expected no users with NULL age, got %d", len(users))
+	}
+
+	err = pool.Read.
+		DB("test_db").
+		Table("users").
+		WhereNotNull("age").
+		WhereBetween("age", 25, 30).
+		GetInto(&users)
+	if err != nil {
+		t.Fatalf("WhereBetween failed: %v", err)
+	}
+
+	for _, user := range users {
+		if !user.Age.Valid || user.Age.Int64 < 25 || user.Age.Int64 > 30 {
+			t.Fatalf("Unexpected age out of range: %+v", user.Age)
+		}
+	}
+
+	t.Logf("Found %d users with age between 25 and 30", len(users))
+}
+
+func TestSubquery(t *testing.T) {
+	profileUsers := pool.Read.
+		DB("test_db").
+		Table("profiles").
+		Select("user_id").
+		Where("is_public", true)
+
+	var users []testUser
+	err := pool.Read.
+		DB("test_db").
+		Table("users").
+		WhereIn("id", profileUsers).
+		GetInto(&users)
+	if err != nil {
+		t.Fatalf("WhereIn subquery failed: %v", err)
+	}
+
+	err = pool.Read.
+		DB("test_db").
+		Table("users").
+		WhereExists(pool.Read.
+			DB("test_db").
+			Table("profiles").
+			Select("1").
+			Where("bio", "Designer")).
+		GetInto(&users)
+	if err != nil {
+		t.Fatalf("WhereExists failed: %v", err)
+	}
+
+	list, err := pool.Read.
+		DB("test_db").
+		FromSub(pool.Read.
+			DB("test_db").
+			Table("users").
+			Select("id", "name").
+			Where("age", ">", 20), "adult").
+		SelectSub(pool.Read.
+			DB("test_db").
+			Table("profiles").
+			Select("COUNT(*)"), "profile_total").
+		GetMaps()
+	if err != nil {
+		t.Fatalf("FromSub failed: %v", err)
+	}
+
+	t.Logf("Found %d users with profiles, %d adults", len(users), len(list))
+}
+
+func TestSelectReplacesBindings(t *testing.T) {
+	sub := pool.Read.
+		DB("test_db").
+		Table("profiles").
+		Select("COUNT(*)").
+		Where("is_public", true)
+
+	query, bindings, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		SelectSub(sub, "profile_total").
+		SelectRaw("age * ? AS double_age", 2).
+		Select("id", "name").
+		Where("age", ">", 20).
+		toSQL()
+	if err != nil {
+		t.Fatalf("Build query failed: %v", err)
+	}
+
+	// 重新 Select 後子查詢與原生片段的綁定參數需一併移除
+	if strings.Count(query, "?") != len(bindings) || len(bindings) != 1 {
+		t.Fatalf("Expected 1 binding, got %d for %s", len(bindings), query)
+	}
+}
+
+func TestGroupByHaving(t *testing.T) {
+	list, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Select("status", "COUNT(*) AS user_count").
+		GroupBy("status").
+		Having("COUNT(*)", ">=", 1).
+		OrderBy("user_count", "DESC").
+		Total().
+		GetMaps()
+	if err != nil {
+		t.Fatalf("GroupBy failed: %v", err)
+	}
+
+	// total 為群組數量而非資料筆數
+	for _, row := range list {
+		if row["total"] != int64(len(list)) {
+			t.Fatalf("Expected total to count groups, got %v of %d", row["total"], len(list))
+		}
+	}
+
+	statusList, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Distinct().
+		Select("status").
+		GetMaps()
+	if err != nil {
+		t.Fatalf("Distinct failed: %v", err)
+	}
+
+	if len(statusList) != len(list) {
+		t.Fatalf("Expected %d distinct status, got %d", len(list), len(statusList))
+	}
+
+	t.Logf("Found %d status groups", len(list))
+}
+
+func TestAggregate(t *testing.T) {
+	count, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("status", "active").
+		OrderBy("id").
+		Limit(1).
+		Count()
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+
+	if count <= 1 {
+		t.Fatalf("Expected Count to ignore LIMIT, got %d", count)
+	}
+
+	sum, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Sum("age")
+	if err != nil {
+		t.Fatalf("Sum failed: %v", err)
+	}
+
+	avg, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Avg("age")
+	if err != nil {
+		t.Fatalf("Avg failed: %v", err)
+	}
+
+	minAge, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Min("age")
+	if err != nil {
+		t.Fatalf("Min failed: %v", err)
+	}
+
+	latest, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Max("created_at")
+	if err != nil {
+		t.Fatalf("Max failed: %v", err)
+	}
+
+	if _, ok := latest.(time.Time); !ok {
+		t.Fatalf("Expected Max(created_at) to be time.Time, got %T", latest)
+	}
+
+	exists, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("email", "john@example.com").
+		Exists()
+	if err != nil || !exists {
+		t.Fatalf("Expected user to exist: %v", err)
+	}
+
+	missing, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("email", "nobody@example.com").
+		DoesntExist()
+	if err != nil || !missing {
+		t.Fatalf("Expected user not to exist: %v", err)
+	}
+
+	groups, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Select("status").
+		GroupBy("status").
+		Count()
+	if err != nil {
+		t.Fatalf("Count groups failed: %v", err)
+	}
+
+	t.Logf("Count=%d Sum=%.0f Avg=%.2f Min=%v Max=%v Groups=%d", count, sum, avg, minAge, latest, groups)
+}
+
+func TestFirstValuePluck(t *testing.T) {
+	var id int64
+	var name string
+	err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Select("id", "name").
+		Where("email", "john@example.com").
+		First(&id, &name)
+	if err != nil {
+		t.Fatalf("First failed: %v", err)
+	}
+
+	err = pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("email", "nobody@example.com").
+		First(&id)
+	if !errors.Is(err, ErrNoRows) {
+		t.Fatalf("Expected ErrNoRows, got: %v", err)
+	}
+
+	email, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		Where("id", id).
+		Value("email")
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+
+	if email != "john@example.com" {
+		t.Fatalf("Expected john@example.com, got %v", email)
+	}
+
+	names, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		OrderBy("id").
+		Pluck("name")
+	if err != nil {
+		t.Fatalf("Pluck failed: %v", err)
+	}
+
+	emailMap, err := pool.Read.
+		DB("test_db").
+		Table("users").
+		PluckMap("id", "email")
+	if err != nil {
+		t.Fatalf("PluckMap failed: %v", err)
+	}
+
+	if emailMap[fmt.Sprintf("%d", id)] != "john@example.com" {
+		t.Fatalf("Unexpected PluckMap result: %v", emailMap)
+	}
+
+	t.Logf("First: %d %s, names: %v", id, name, names)
+}
+
+type testNewUser struct {
+	ID     int64  `db:"id,omitempty"`
+	Name   string `db:"name"`
+	Email  string `db:"email"`
+	Age    int    `db:"age"`
+	Status string `db:"status,omitempty"`
+}
+
+func TestInsertMany(t *testing.T) {
+	result, err := pool.Write.
+		DB("test_db").
+		Table("users").
+		InsertMany([]map[string]interface{}{
+			{"name": "Batch One", "email": "batch1@example.com", "age": 21},
+			{"name": "Batch Two", "email": "batch2@example.com", "age": 22, "status": "inactive"},
+		})
+	if err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+
+	if result.RowsAffected != 2 || result.FirstInsertID <= 0 {
+		t.Fatalf("Unexpected batch result: %+v", result)
+	}
+
+	result, err = pool.Write.
+		DB("test_db").
+		Table("users").
+		InsertStructs([]testNewUser{
+			{Name: "Batch Three", Email: "batch3@example.com", Age: 23},
+			{Name: "Batch Four", Email: "batch4@example.com", Age: 24, Status: "inactive"},
+		})
+	if err != nil {
+		t.Fatalf("InsertStructs failed: %v", err)
+	}
+
+	if result.RowsAffected != 2 {
+		t.Fatalf("Expected 2 rows inserted, got %d", result.RowsAffected)
+	}
+
+	t.Logf("Batch inserted from ID: %d", result.FirstInsertID)
+}
+
+func TestInsertStructAndPair(t *testing.T) {
+	lastID, err := pool.Write.
+		DB("test_db").
+		Table("users").
+		Insert(testNewUser{
+			Name:  "Struct User",
+			Email: "struct@example.com",
+			Age:   33,
+		})
+	if err != nil {
+		t.Fatalf("Insert struct failed: %v", err)
+	}
+
+	result, err := pool.Write.
+		DB("test_db").
+		Table("users").
+		Where("id", lastID).
+		Update([]Pair{
+			{Column: "status", Value: "inactive"},
+			{Column: "age", Value: 34},
+		})
+	if err != nil {
+		t.Fatalf("Update with pairs failed: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil || rowsAffected != 1 {
+		t.Fatalf("Expected 1 row updated, got %d: %v", rowsAffected, err)
+	}
+
+	t.Logf("Inserted struct user with ID: %d", lastID)
+}
+
+func TestDeterministicColumns(t *testing.T) {
+	row := map[string]interface{}{}
+	for i := 0; i < 32; i++ {
+		row[fmt.Sprintf("col_%02d", 31-i)] = i
+	}
+
+	builder := pool.Write.DB("test_db").Table("users")
+	columnsOf := func(query string) []string {
+		start := strings.Index(query, "(") + 1
+		return strings.Split(query[start:strings.Index(query, ")")], ", ")
+	}
+
+	// 多次產生的 SQL 文字需完全相同，且欄位依名稱排序
+	var upsertSQL, insertSQL string
+	for i := 0; i < 20; i++ {
+		query, _, err := builder.upsertQuery(row)
+		if err != nil {
+			t.Fatalf("Build upsert failed: %v", err)
+		}
+		if i > 0 && query != upsertSQL {
+			t.Fatalf("Upsert SQL changed between calls:\n%s\n%s", upsertSQL, query)
+		}
+		upsertSQL = query
+
+		chunks := builder.insertChunks("INSERT", []map[string]interface{}{row, row}, defaultMaxPacketSize)
+		if len(chunks) != 1 {
+			t.Fatalf("Expected 1 chunk, got %d", len(chunks))
+		}
+		if i > 0 && chunks[0].query != insertSQL {
+			t.Fatalf("Insert SQL changed between calls:\n%s\n%s", insertSQL, chunks[0].query)
+		}
+		insertSQL = chunks[0].query
+	}
+
+	for _, query := range []string{upsertSQL, insertSQL} {
+		columns := columnsOf(query)
+		if len(columns) != len(row) || !sort.StringsAreSorted(columns) {
+			t.Fatalf("Expected %d sorted columns, got %v", len(row), columns)
+		}
+	}
+}
+
+func TestInsertIgnoreReplaceUsing(t *testing.T) {
+	_, err := pool.Write.Exec(`
+        CREATE TABLE IF NOT EXISTS test_db.users_archive (
+            id INT PRIMARY KEY,
+            name VARCHAR(100) NOT NULL,
+            email VARCHAR(100) NOT NULL
+        )
+    `)
+	if err != nil {
+		t.Fatalf("Failed to create archive table: %v", err)
+	}
+
+	// 測試 INSERT ... SELECT
+	result, err := pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		InsertUsing([]string{"id", "name", "email"}, pool.Write.
+			DB("test_db").
+			Table("users").
+			Select("id", "name", "email").
+			Where("status", "inactive"))
+	if err != nil {
+		t.Fatalf("InsertUsing failed: %v", err)
+	}
+
+	copied, _ := result.RowsAffected()
+
+	var id int64
+	err = pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		Select("id").
+		First(&id)
+	if err != nil {
+		t.Fatalf("Failed to read archived row: %v", err)
+	}
+
+	// 重複主鍵應被略過
+	_, err = pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		InsertIgnore(map[string]interface{}{"id": id, "name": "Ignored", "email": "ignored@example.com"})
+	if err != nil {
+		t.Fatalf("InsertIgnore failed: %v", err)
+	}
+
+	_, err = pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		Replace(map[string]interface{}{"id": id, "name": "Replaced", "email": "replaced@example.com"})
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+
+	name, err := pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		Where("id", id).
+		Value("name")
+	if err != nil || name != "Replaced" {
+		t.Fatalf("Expected replaced row, got %v: %v", name, err)
+	}
+
+	t.Logf("Archived %d users", copied)
+}
+
+func TestUpsertColumns(t *testing.T) {
+	data := map[string]interface{}{
+		"id":    9001,
+		"name":  "Upsert Columns",
+		"email": "upsert.columns@example.com",
+	}
+
+	_, err := pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		Upsert(data)
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	// 只更新指定欄位
+	data["name"] = "Upsert Renamed"
+	data["email"] = "changed@example.com"
+	_, err = pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		Upsert(data, []string{"name"})
+	if err != nil {
+		t.Fatalf("Upsert with columns failed: %v", err)
+	}
+
+	row, err := pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		Where("id", 9001).
+		FirstMap()
+	if err != nil {
+		t.Fatalf("Failed to read upserted row: %v", err)
+	}
+
+	if row["name"] != "Upsert Renamed" || row["email"] != "upsert.columns@example.com" {
+		t.Fatalf("Unexpected upserted row: %v", row)
+	}
+}
+
+func TestUpsertOutcome(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"id": 9101, "name": "Outcome A", "email": "outcome.a@example.com"},
+		{"id": 9102, "name": "Outcome B", "email": "outcome.b@example.com"},
+	}
+
+	// 非自動遞增主鍵於新增時由資料列取得 id
+	result, err := pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		ReturnID("id").
+		UpsertMany(rows)
+	if err != nil {
+		t.Fatalf("UpsertMany failed: %v", err)
+	}
+	if result.Inserted != 2 || len(result.IDs) != 2 || result.IDs[0] != 9101 || result.IDs[1] != 9102 {
+		t.Fatalf("Expected 2 inserted rows with ids, got %+v", result)
+	}
+
+	// 未設定 ReturnID 時不產生 LAST_INSERT_ID 也不回傳 IDs
+	result, err = pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		UpsertMany(rows)
+	if err != nil {
+		t.Fatalf("UpsertMany without ReturnID failed: %v", err)
+	}
+	if result.Unchanged != 2 || result.IDs != nil {
+		t.Fatalf("Expected 2 unchanged rows without ids, got %+v", result)
+	}
+
+	// 一筆更新、一筆不變
+	rows[0]["name"] = "Outcome A2"
+	result, err = pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		ReturnID("id").
+		UpsertMany(rows)
+	if err != nil {
+		t.Fatalf("Second UpsertMany failed: %v", err)
+	}
+	if result.Updated != 1 || result.Unchanged != 1 || result.IDs[0] != 9101 || result.IDs[1] != 9102 {
+		t.Fatalf("Expected 1 updated and 1 unchanged row, got %+v", result)
+	}
+
+	single, err := pool.Write.
+		DB("test_db").
+		Table("users_archive").
+		ReturnID("id").
+		Upsert(map[string]interface{}{"id": 9102, "name": "Outcome B2", "email": "outcome.b@example.com"})
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if single.Outcome != UpsertUpdated || single.ID != 9102 {
+		t.Fatalf("Expected updated row 9102, got %+v", single)
+	}
+}
+
+func TestRawExpression(t *testing.T) {
+	id, err := pool.Write.
+		DB("test_db").
+		Table("users").
+		Insert(map[string]interface{}{
+			"name":       "Raw User",
+			"email":      "raw@example.com",
+			"age":        20,
+			"status":     "NOW()",
+			"created_at": Raw("DATE_SUB(NOW(), INTERVAL ? DAY)", 3),
+		})
+	if err != nil {
+		t.Fatalf("Insert with Raw failed: %v", err)
+	}
+
+	_, err = pool.Write.
+		DB("test_db").
+		Table("users").
+		Where("id", id).
+		Update(map[string]interface{}{
+			"age": Raw("age + ?", 5),
+		})
+	if err != nil {
+		t.Fatalf("Update with Raw failed: %v", err)
+	}
+
+	// 字串一律綁定，不會被當成函式
+	row, err := pool.Write.
+		DB("test_db").
+		Table("users").
+		Select("age", "status").
+		SelectRaw("DATEDIFF(NOW(), created_at) AS days").
+		Where("id", id).
+		Where("created_at", "<", Raw("DATE_SUB(NOW(), INTERVAL ? DAY)", 1)).
+		WhereRaw("age > ? OR status = ?", 100, "NOW()").
+		OrderByRaw("FIELD(status, ?)", "NOW()").
+		FirstMap()
+	if err != nil {
+		t.Fatalf("Select with Raw failed: %v", err)
+	}
+
+	if row["age"] != int64(25) || row["status"] != "NOW()" || row["days"] != int64(3) {
+		t.Fatalf("Unexpected raw row: %v", row)
+	}
+}
+
+func TestDBQualification(t *testing.T) {
+	errs := make(chan error, 20)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, err := pool.Read.DB("test_db").Table("users").Count()
+			errs <- err
+		}()
+		go func() {
+			_, err := pool.Read.DB("information_schema").Table("SCHEMATA").Count()
+			errs <- err
+		}()
+	}
+
+	// 同一連接池並行使用不同資料庫
+	for i := 0; i < 20; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("Query on qualified table failed: %v", err)
+		}
+	}
+}
+
+func TestReadReplicas(t *testing.T) {
+	endpoint := func(weight int) *DBConfig {
+		return &DBConfig{
+			Host:       "localhost",
+			Port:       3306,
+			User:       "root",
+			Password:   "password",
+			Connection: 2,
+			Weight:     weight,
+		}
+	}
+
+	for _, strategy := range []Strategy{StrategyRoundRobin, StrategyRandom, StrategyWeighted, StrategyLeastInFlight} {
+		replicaPool, err := New(Config{
+			Reads:    []*DBConfig{endpoint(1), endpoint(3)},
+			Strategy: strategy,
+			Write:    endpoint(1),
+			Log:      &Log{Path: "./logs/mysql-pool-test"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to initialize replica pool: %v", err)
+		}
+
+		// 每個副本各自持有連線
+		for i := 0; i < 6; i++ {
+			if _, err := replicaPool.Read.DB("test_db").Table("users").Count(); err != nil {
+				t.Fatalf("Query on %s replica failed: %v", strategy, err)
+			}
+		}
+
+		if len(replicaPool.Read.replicas) != 2 {
+			t.Fatalf("Expected 2 replicas, got %d", len(replicaPool.Read.replicas))
+		}
+
+		replicaPool.Close()
+	}
+}
+
+func TestReplicaHealth(t *testing.T) {
+	healthy := &DBConfig{Host: "localhost", Port: 3306, User: "root", Password: "password"}
+	down := &DBConfig{Host: "127.0.0.1", Port: 1, User: "root", Password: "password"}
+
+	healthPool, err := New(Config{
+		Reads:  []*DBConfig{down, healthy},
+		Write:  healthy,
+		Log:    &Log{Path: "./logs/mysql-pool-test"},
+		Health: &HealthConfig{Interval: 100 * time.Millisecond, Timeout: time.Second},
+	})
+	if err != nil {
+		t.Fatalf("New should tolerate a down replica: %v", err)
+	}
+	defer healthPool.Close()
+
+	health := healthPool.Read.Health()
+	if len(health) != 2 || health[0].Healthy || !health[1].Healthy {
+		t.Fatalf("Unexpected replica health: %+v", health)
+	}
+
+	for i := 0; i < 4; i++ {
+		if _, err := healthPool.Read.DB("test_db").Table("users").Count(); err != nil {
+			t.Fatalf("Query should skip the down replica: %v", err)
+		}
+	}
+
+	// 所有副本失效時退回寫入池
+	fallbackPool, err := New(Config{
+		Reads: []*DBConfig{down},
+		Write: healthy,
+		Log:   &Log{Path: "./logs/mysql-pool-test"},
+	})
+	if err != nil {
+		t.Fatalf("New should fall back to write pool: %v", err)
+	}
+	defer fallbackPool.Close()
+
+	if _, err := fallbackPool.Read.DB("test_db").Table("users").Count(); err != nil {
+		t.Fatalf("Query should fall back to write pool: %v", err)
+	}
+}
+
+func TestReplicaLag(t *testing.T) {
+	_, err := pool.Write.Exec("CREATE TABLE IF NOT EXISTS test_db.heartbeat (id INT PRIMARY KEY, ts VARCHAR(26) NOT NULL)")
+	if err != nil {
+		t.Fatalf("Failed to create heartbeat table: %v", err)
+	}
+
+	// 模擬落後一小時的副本
+	_, err = pool.Write.Exec("REPLACE INTO test_db.heartbeat (id, ts) VALUES (1, DATE_FORMAT(UTC_TIMESTAMP(6) - INTERVAL 1 HOUR, '%Y-%m-%dT%H:%i:%s.%f'))")
+	if err != nil {
+		t.Fatalf("Failed to write heartbeat: %v", err)
+	}
+
+	endpoint := &DBConfig{Host: "localhost", Port: 3306, User: "root", Password: "password"}
+	logPath := t.TempDir()
+	lagPool, err := New(Config{
+		Reads: []*DBConfig{endpoint},
+		Write: endpoint,
+		Log:   &Log{Path: logPath},
+		Health: &HealthConfig{
+			MaxLag:    time.Minute,
+			Heartbeat: "test_db.heartbeat",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize lag pool: %v", err)
+	}
+	defer lagPool.Close()
+
+	health := lagPool.Read.Health()
+	if !health[0].Healthy || !health[0].Lagging || health[0].Lag < 59*time.Minute {
+		t.Fatalf("Expected lagging replica, got %+v", health[0])
+	}
+
+	if lagPool.Read.pick() != lagPool.Write.db {
+		t.Fatal("Expected read to fall back to write pool")
+	}
+
+	// 啟動時所有副本皆落後也需記錄
+	output, err := os.ReadFile(filepath.Join(logPath, "output.log"))
+	if err != nil || !strings.Contains(string(output), "Every read replica exceeds max lag") {
+		t.Fatalf("Expected max lag warning in log, got %q: %v", output, err)
+	}
+
+	if _, err := pool.Write.Exec("DROP TABLE IF EXISTS test_db.heartbeat"); err != nil {
+		t.Fatalf("Failed to drop heartbeat table: %v", err)
+	}
+}
+
+func TestReadYourWrites(t *testing.T) {
+	endpoint := &DBConfig{Host: "localhost", Port: 3306, User: "root", Password: "password"}
+	rywPool, err := New(Config{
+		Reads:       []*DBConfig{endpoint},
+		Write:       endpoint,
+		Log:         &Log{Path: "./logs/mysql-pool-test"},
+		Consistency: &ConsistencyConfig{Wait: time.Second, Window: time.Minute},
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize pool: %v", err)
+	}
+	defer rywPool.Close()
+
+	var token Token
+	_, err = rywPool.Write.
+		DB("test_db").
+		Table("users").
+		Track(&token).
+		Where("email", "john@example.com").
+		Update(map[string]interface{}{"status": "fresh"})
+	if err != nil {
+		t.Fatalf("Tracked update failed: %v", err)
+	}
+
+	if token.IsZero() {
+		t.Fatal("Expected token after write")
+	}
+
+	// 無 GTID 時於時間窗內讀取主庫，有 GTID 時副本已套用
+	expected := rywPool.Read.replicas[0].db
+	if token.GTID == "" {
+		expected = rywPool.Write.db
+	}
+	if rywPool.Read.After(token).pickContext(context.Background()) != expected {
+		t.Fatalf("Unexpected routing for token %+v", token)
+	}
+
+	// 建立建構器時不等待副本，於語句執行時依 ctx 選擇
+	builder := rywPool.Read.After(token).DB("test_db")
+	if builder.db != nil || builder.after == nil {
+		t.Fatal("Expected replica to be picked on first statement")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := builder.WithContext(ctx).Table("users").Count(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected cancelled read, got %v", err)
+	}
+
+	status, err := rywPool.Read.
+		After(token).
+		DB("test_db").
+		Table("users").
+		Where("email", "john@example.com").
+		Value("status")
+	if err != nil || status != "fresh" {
+		t.Fatalf("Expected fresh status, got %v: %v", status, err)
+	}
+
+	// 交易內追蹤的 token 於提交後與直接查詢的 GTID 相同
+	var txToken, execToken Token
+	err = rywPool.Write.Transaction(context.Background(), func(tx *Tx) error {
+		_, err := tx.DB("test_db").
+			Table("users").
+			Track(&txToken).
+			Where("email", "john@example.com").
+			Update(map[string]interface{}{"status": "active"})
+		if err != nil {
+			return err
+		}
+
+		tx.Track(&execToken)
+		_, err = tx.Exec("UPDATE test_db.users SET status = ? WHERE email = ?", "active", "jane@example.com")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Tracked transaction failed: %v", err)
+	}
+
+	committed := rywPool.Write.Token(context.Background())
+	if txToken.GTID != committed.GTID || execToken.GTID != committed.GTID {
+		t.Fatalf("Expected GTID %q after commit, got %q / %q", committed.GTID, txToken.GTID, execToken.GTID)
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	// 清理測試資料
+	_, err := pool.Write.Exec("DROP TABLE IF EXISTS test_db.users_archive")
+	if err != nil {
+		t.Logf("Warning: Failed to drop users_archive table: %v", err)
+	}
+
+	_, err = pool.Write.Exec("DROP TABLE IF EXISTS test_db.profiles")
 	if err != nil {
 		t.Logf("Warning: Failed to drop profiles table: %v", err)
 	}
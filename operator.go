@@ -1,19 +1,38 @@
 package goMysql
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"reflect"
+	"sync"
 	"time"
 )
 
+const (
+	killQueryTimeout = 5 * time.Second
+	killPoolSize     = 2
+)
+
+var (
+	// * *sql.DB -> small *sql.DB on the same endpoint, only used for KILL QUERY
+	killPools sync.Map
+	// * driver connection -> CONNECTION_ID(), saves a round trip per cancellable statement
+	connIDs sync.Map
+)
+
 func (p *Pool) Query(query string, params ...interface{}) (*sql.Rows, error) {
-	if p.db == nil {
+	return p.QueryContext(context.Background(), query, params...)
+}
+
+func (p *Pool) QueryContext(ctx context.Context, query string, params ...interface{}) (*sql.Rows, error) {
+	db := p.pickContext(ctx)
+	if db == nil {
 		return nil, p.logger.Error(nil, "Database connection is not available")
 	}
 
-	startTime := time.Now()
-	rows, err := p.db.Query(query, params...)
-	duration := time.Since(startTime)
+	rows, duration, err := queryContext(ctx, db, query, params...)
 
 	if duration > 20*time.Millisecond {
 		p.logger.Info(fmt.Sprintf("Slow Query %s", duration))
@@ -23,13 +42,16 @@ func (p *Pool) Query(query string, params ...interface{}) (*sql.Rows, error) {
 }
 
 func (p *Pool) Exec(query string, params ...interface{}) (sql.Result, error) {
-	if p.db == nil {
+	return p.ExecContext(context.Background(), query, params...)
+}
+
+func (p *Pool) ExecContext(ctx context.Context, query string, params ...interface{}) (sql.Result, error) {
+	db := p.pickContext(ctx)
+	if db == nil {
 		return nil, p.logger.Error(nil, "Database connection is not available")
 	}
 
-	startTime := time.Now()
-	result, err := p.db.Exec(query, params...)
-	duration := time.Since(startTime)
+	result, duration, err := execContext(ctx, db, query, params...)
 
 	if duration > 20*time.Millisecond {
 		p.logger.Debug(fmt.Sprintf("Slow Query %s", duration))
@@ -39,13 +61,17 @@ func (p *Pool) Exec(query string, params ...interface{}) (sql.Result, error) {
 }
 
 func (b *builder) query(query string, params ...interface{}) (*sql.Rows, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	b.resolve()
+
 	if b.db == nil {
 		return nil, b.logger.Error(nil, "Database connection is not available")
 	}
 
-	startTime := time.Now()
-	rows, err := b.db.Query(query, params...)
-	duration := time.Since(startTime)
+	rows, duration, err := queryContext(b.ctx, b.db, query, params...)
 
 	if duration > 20*time.Millisecond {
 		b.logger.Debug(fmt.Sprintf("Slow Query %s", duration))
@@ -55,17 +81,180 @@ func (b *builder) query(query string, params ...interface{}) (*sql.Rows, error)
 }
 
 func (b *builder) exec(query string, params ...interface{}) (sql.Result, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	b.resolve()
+
 	if b.db == nil {
 		return nil, b.logger.Error(nil, "Database connection is not available")
 	}
 
-	startTime := time.Now()
-	result, err := b.db.Exec(query, params...)
-	duration := time.Since(startTime)
+	result, duration, err := execContext(b.ctx, b.db, query, params...)
 
 	if duration > 20*time.Millisecond {
 		b.logger.Info(fmt.Sprintf("Slow Query %s", duration))
 	}
 
+	if err == nil {
+		b.trackToken()
+	}
+
 	return result, err
 }
+
+// * private method
+// * driver only drops the client connection once ctx is done, kill the statement on server as well
+// * duration covers the statement only, not the connection checkout
+func queryContext(ctx context.Context, exec executor, query string, params ...interface{}) (*sql.Rows, time.Duration, error) {
+	db, ok := exec.(*sql.DB)
+	if !ok || ctx.Done() == nil {
+		startTime := time.Now()
+		rows, err := exec.QueryContext(ctx, query, params...)
+		return rows, time.Since(startTime), err
+	}
+
+	conn, key, stop, err := killableConn(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	startTime := time.Now()
+	rows, err := conn.QueryContext(ctx, query, params...)
+	duration := time.Since(startTime)
+	killed := stop()
+	if err != nil {
+		releaseConn(conn, key, killed)
+		return nil, duration, err
+	}
+
+	// * blocks until rows are closed, then returns the connection to the pool
+	go releaseConn(conn, key, killed)
+
+	return rows, duration, nil
+}
+
+// * private method
+func execContext(ctx context.Context, exec executor, query string, params ...interface{}) (sql.Result, time.Duration, error) {
+	db, ok := exec.(*sql.DB)
+	if !ok || ctx.Done() == nil {
+		startTime := time.Now()
+		result, err := exec.ExecContext(ctx, query, params...)
+		return result, time.Since(startTime), err
+	}
+
+	conn, key, stop, err := killableConn(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	startTime := time.Now()
+	result, err := conn.ExecContext(ctx, query, params...)
+	duration := time.Since(startTime)
+	releaseConn(conn, key, stop())
+
+	return result, duration, err
+}
+
+// * private method
+// * stop reports whether KILL QUERY was sent, the kill itself runs in background
+func killableConn(ctx context.Context, db *sql.DB) (*sql.Conn, interface{}, func() bool, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	key, connID, err := connectionID(ctx, conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, err
+	}
+
+	done := make(chan struct{})
+	decided := make(chan bool, 1)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			decided <- true
+			killQuery(db, connID)
+		case <-done:
+			decided <- false
+		}
+	}()
+
+	stop := func() bool {
+		close(done)
+		return <-decided
+	}
+
+	return conn, key, stop, nil
+}
+
+// * private method
+// * CONNECTION_ID() is looked up once per driver connection, key is nil when it cannot be cached
+func connectionID(ctx context.Context, conn *sql.Conn) (interface{}, int64, error) {
+	var key interface{}
+	_ = conn.Raw(func(driverConn interface{}) error {
+		if reflect.ValueOf(driverConn).Kind() == reflect.Ptr {
+			key = driverConn
+		}
+		return nil
+	})
+
+	if key != nil {
+		if id, ok := connIDs.Load(key); ok {
+			return key, id.(int64), nil
+		}
+	}
+
+	var connID int64
+	if err := conn.QueryRowContext(ctx, "SELECT CONNECTION_ID()").Scan(&connID); err != nil {
+		return nil, 0, err
+	}
+
+	if key != nil {
+		pruneConnIDs()
+		connIDs.Store(key, connID)
+	}
+	return key, connID, nil
+}
+
+// * private method
+// * connections closed by the pool itself (lifetime, idle) are dropped when a new one is cached
+func pruneConnIDs() {
+	connIDs.Range(func(key, _ interface{}) bool {
+		if v, ok := key.(driver.Validator); ok && !v.IsValid() {
+			connIDs.Delete(key)
+		}
+		return true
+	})
+}
+
+// * private method
+// * sent through the kill pool of db, a full pool never delays the cancelled caller
+func killQuery(db *sql.DB, connID int64) {
+	killer := db
+	if v, ok := killPools.Load(db); ok {
+		killer = v.(*sql.DB)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), killQueryTimeout)
+	defer cancel()
+	_, _ = killer.ExecContext(ctx, fmt.Sprintf("KILL QUERY %d", connID))
+}
+
+// * private method
+// * a killed connection may still receive KILL QUERY late, drop it instead of reusing
+func releaseConn(conn *sql.Conn, key interface{}, killed bool) {
+	if killed {
+		if key != nil {
+			connIDs.Delete(key)
+		}
+		_ = conn.Raw(func(interface{}) error {
+			return driver.ErrBadConn
+		})
+	}
+	conn.Close()
+}
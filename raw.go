@@ -0,0 +1,58 @@
+package goMysql
+
+// * raw SQL fragment, bindings are passed to the driver and never interpolated
+type Expression struct {
+	sql      string
+	bindings []interface{}
+}
+
+func Raw(sql string, bindings ...interface{}) Expression {
+	return Expression{sql: sql, bindings: bindings}
+}
+
+// * bound for Increase / Decrease, the result is clamped through GREATEST / LEAST
+type Guard struct {
+	function string
+	value    interface{}
+}
+
+func AtLeast(min interface{}) Guard {
+	return Guard{function: "GREATEST", value: min}
+}
+
+func AtMost(max interface{}) Guard {
+	return Guard{function: "LEAST", value: max}
+}
+
+// * appended to the select list, which starts as *
+func (b *builder) SelectRaw(sql string, bindings ...interface{}) *builder {
+	b.selectList = append(b.selectList, sql)
+	b.selectBindings = append(b.selectBindings, bindings...)
+	return b
+}
+
+// * wrapped in parentheses, so OR inside the fragment keeps its precedence
+func (b *builder) WhereRaw(sql string, bindings ...interface{}) *builder {
+	b.addWhere("AND", "("+sql+")", bindings...)
+	return b
+}
+
+func (b *builder) OrWhereRaw(sql string, bindings ...interface{}) *builder {
+	b.addWhere("OR", "("+sql+")", bindings...)
+	return b
+}
+
+func (b *builder) OrderByRaw(sql string, bindings ...interface{}) *builder {
+	b.orderList = append(b.orderList, sql)
+	b.orderBindings = append(b.orderBindings, bindings...)
+	return b
+}
+
+// * private method
+// * plain value is bound, Expression is inlined with its own bindings
+func placeholder(value interface{}) (string, []interface{}) {
+	if expr, ok := value.(Expression); ok {
+		return expr.sql, expr.bindings
+	}
+	return "?", []interface{}{value}
+}
@@ -0,0 +1,120 @@
+package goMysql
+
+import (
+	"database/sql"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Strategy string
+
+const (
+	StrategyRoundRobin    Strategy = "round_robin"
+	StrategyRandom        Strategy = "random"
+	StrategyWeighted      Strategy = "weighted"
+	StrategyLeastInFlight Strategy = "least_in_flight"
+)
+
+type replica struct {
+	db      *sql.DB
+	name    string
+	weight  int
+	healthy atomic.Bool
+	lagging atomic.Bool
+	// * private
+	mu        sync.Mutex
+	err       error
+	lag       time.Duration
+	checkedAt time.Time
+}
+
+// * private method
+// * write pool has no replicas and always uses its own connection
+func (p *Pool) pick() *sql.DB {
+	if len(p.replicas) == 0 {
+		return p.db
+	}
+
+	list := make([]*replica, 0, len(p.replicas))
+	for _, r := range p.replicas {
+		if r.healthy.Load() && !r.lagging.Load() {
+			list = append(list, r)
+		}
+	}
+
+	switch {
+	case len(list) == 0:
+		return p.fallback
+	case len(list) == 1:
+		return list[0].db
+	}
+
+	switch p.strategy {
+	case StrategyRandom:
+		return list[rand.IntN(len(list))].db
+	case StrategyWeighted:
+		return pickWeighted(list).db
+	case StrategyLeastInFlight:
+		return pickLeastInFlight(list).db
+	default:
+		index := p.next.Add(1) - 1
+		return list[index%uint64(len(list))].db
+	}
+}
+
+// * private method
+func pickWeighted(list []*replica) *replica {
+	total := 0
+	for _, r := range list {
+		total += r.weight
+	}
+
+	n := rand.IntN(total)
+	for _, r := range list {
+		if n < r.weight {
+			return r
+		}
+		n -= r.weight
+	}
+	return list[len(list)-1]
+}
+
+// * private method
+// * connections currently in use, ties go to the first replica
+func pickLeastInFlight(list []*replica) *replica {
+	target := list[0]
+	inUse := target.db.Stats().InUse
+	for _, r := range list[1:] {
+		if count := r.db.Stats().InUse; count < inUse {
+			target, inUse = r, count
+		}
+	}
+	return target
+}
+
+// * private method
+// * returns true when the state changed
+func (r *replica) setHealth(err error) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.err = err
+	r.checkedAt = time.Now()
+	return r.healthy.Swap(err == nil) != (err == nil)
+}
+
+// * private method
+// * lag unknown because of err counts as lagging
+func (r *replica) setLag(lag time.Duration, lagging bool, err error) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lag = lag
+	if err != nil {
+		r.err = err
+		lagging = true
+	}
+	return r.lagging.Swap(lagging) != lagging
+}
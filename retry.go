@@ -0,0 +1,41 @@
+package goMysql
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func (p *Pool) SetRetry(c RetryConfig) *Pool {
+	p.retry = validRetryConfig(Config{Retry: &c})
+	return p
+}
+
+// * private method
+func (r *RetryConfig) retryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if r == nil || !errors.As(err, &mysqlErr) {
+		return false
+	}
+
+	for _, code := range r.Codes {
+		if mysqlErr.Number == code {
+			return true
+		}
+	}
+	return false
+}
+
+// * private method
+// * exponential backoff with jitter, between half and full delay
+func (r *RetryConfig) backoff(attempt int) time.Duration {
+	delay := r.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
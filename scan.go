@@ -0,0 +1,255 @@
+package goMysql
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+var (
+	// * reflect.Type -> map[column]structField
+	structFieldCache sync.Map
+	scannerType      = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType         = reflect.TypeOf(time.Time{})
+)
+
+type structField struct {
+	index     []int
+	omitEmpty bool
+}
+
+func (b *builder) Strict() *builder {
+	b.strict = true
+	return b
+}
+
+func (b *builder) GetInto(dest interface{}) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Slice {
+		return b.logger.Error(nil, "Destination must be a pointer to slice")
+	}
+
+	slice := value.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return b.logger.Error(nil, "Destination must be a slice of struct")
+	}
+
+	rows, err := b.Get()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	indexes, err := b.columnIndexes(rows, structType)
+	if err != nil {
+		return err
+	}
+
+	list := reflect.MakeSlice(slice.Type(), 0, 0)
+	for rows.Next() {
+		item := reflect.New(structType)
+		if err := scanStruct(rows, indexes, item.Elem()); err != nil {
+			return err
+		}
+
+		if elemType.Kind() == reflect.Pointer {
+			list = reflect.Append(list, item)
+		} else {
+			list = reflect.Append(list, item.Elem())
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	slice.Set(list)
+	return nil
+}
+
+func (b *builder) FirstInto(dest interface{}) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return b.logger.Error(nil, "Destination must be a pointer to struct")
+	}
+
+	one := 1
+	b.limit = &one
+
+	rows, err := b.Get()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	indexes, err := b.columnIndexes(rows, value.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return ErrNoRows
+	}
+
+	if err := scanStruct(rows, indexes, value.Elem()); err != nil {
+		return err
+	}
+
+	return rows.Err()
+}
+
+func Find[T any](b *builder) ([]T, error) {
+	var list []T
+	if err := b.GetInto(&list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// * private method
+func (b *builder) columnIndexes(rows *sql.Rows, structType reflect.Type) ([][]int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := structFields(structType)
+	indexes := make([][]int, len(columns))
+	unmapped := []string{}
+
+	for i, column := range columns {
+		if field, ok := fields[column]; ok {
+			indexes[i] = field.index
+		} else {
+			unmapped = append(unmapped, column)
+		}
+	}
+
+	if b.strict && len(unmapped) > 0 {
+		return nil, b.logger.Error(nil, "Unmapped columns for "+structType.String()+": "+strings.Join(unmapped, ", "))
+	}
+
+	return indexes, nil
+}
+
+// * private method
+func scanStruct(rows *sql.Rows, indexes [][]int, target reflect.Value) error {
+	dest := make([]interface{}, len(indexes))
+	for i, index := range indexes {
+		if index == nil {
+			dest[i] = new(interface{})
+			continue
+		}
+		dest[i] = fieldByIndex(target, index).Addr().Interface()
+	}
+	return rows.Scan(dest...)
+}
+
+// * private method
+// * allocates nil embedded pointers along the path
+func fieldByIndex(value reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(x)
+	}
+	return value
+}
+
+// * private method
+func structFields(structType reflect.Type) map[string]structField {
+	if cached, ok := structFieldCache.Load(structType); ok {
+		return cached.(map[string]structField)
+	}
+
+	fields := map[string]structField{}
+	collectFields(structType, nil, fields)
+	structFieldCache.Store(structType, fields)
+	return fields
+}
+
+// * private method
+func collectFields(structType reflect.Type, parent []int, fields map[string]structField) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		index := append(append([]int{}, parent...), i)
+
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				// * unexported embedded pointer cannot be allocated
+				if !field.IsExported() {
+					continue
+				}
+				fieldType = fieldType.Elem()
+			}
+
+			if fieldType.Kind() == reflect.Struct && !isScannable(fieldType) {
+				collectFields(fieldType, index, fields)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = toSnakeCase(field.Name)
+		}
+
+		// * shallower field wins, same as Go field promotion
+		if exist, ok := fields[name]; !ok || len(index) < len(exist.index) {
+			fields[name] = structField{
+				index:     index,
+				omitEmpty: options == "omitempty",
+			}
+		}
+	}
+}
+
+// * private method
+func isScannable(fieldType reflect.Type) bool {
+	return fieldType == timeType || reflect.PointerTo(fieldType).Implements(scannerType)
+}
+
+// * private method
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,76 @@
+package goMysql
+
+import "fmt"
+
+// * appended to the select list, which starts as *
+func (b *builder) SelectSub(sub *Builder, alias string) *builder {
+	query, bindings, ok := b.subquery(sub)
+	if !ok {
+		return b
+	}
+
+	b.selectList = append(b.selectList, fmt.Sprintf("(%s) AS `%s`", query, alias))
+	b.selectBindings = append(b.selectBindings, bindings...)
+	return b
+}
+
+func (b *builder) FromSub(sub *Builder, alias string) *builder {
+	query, bindings, ok := b.subquery(sub)
+	if !ok {
+		return b
+	}
+
+	fromClause := fmt.Sprintf("(%s) AS `%s`", query, alias)
+	b.fromClause = &fromClause
+	b.fromBindings = bindings
+	return b
+}
+
+func (b *builder) WhereExists(sub *Builder) *builder {
+	return b.whereExists("AND", false, sub)
+}
+
+func (b *builder) OrWhereExists(sub *Builder) *builder {
+	return b.whereExists("OR", false, sub)
+}
+
+func (b *builder) WhereNotExists(sub *Builder) *builder {
+	return b.whereExists("AND", true, sub)
+}
+
+func (b *builder) OrWhereNotExists(sub *Builder) *builder {
+	return b.whereExists("OR", true, sub)
+}
+
+// * private method
+func (b *builder) whereExists(boolean string, not bool, sub *builder) *builder {
+	query, bindings, ok := b.subquery(sub)
+	if !ok {
+		return b
+	}
+
+	operator := "EXISTS"
+	if not {
+		operator = "NOT EXISTS"
+	}
+
+	b.addWhere(boolean, fmt.Sprintf("%s (%s)", operator, query), bindings...)
+	return b
+}
+
+// * private method
+// * failure is kept on the builder, so a dropped condition never reaches the database
+func (b *builder) subquery(sub *builder) (string, []interface{}, bool) {
+	if sub == nil {
+		b.err = b.logger.Error(nil, "Subquery is required")
+		return "", nil, false
+	}
+
+	query, bindings, err := sub.toSQL()
+	if err != nil {
+		b.err = err
+		return "", nil, false
+	}
+
+	return query, bindings, true
+}
@@ -0,0 +1,171 @@
+package goMysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+func (p *Pool) Transaction(ctx context.Context, fn func(tx *Tx) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	maxAttempts := 1
+	if p.retry != nil {
+		maxAttempts = p.retry.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := p.transaction(ctx, fn)
+		if err == nil || attempt >= maxAttempts || !p.retry.retryable(err) {
+			return err
+		}
+
+		delay := p.retry.backoff(attempt)
+		p.logger.Warn(fmt.Sprintf("Retry transaction (%d/%d) in %s: %v", attempt+1, maxAttempts, delay, err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// * private method
+func (p *Pool) transaction(ctx context.Context, fn func(tx *Tx) error) error {
+	db := p.pickContext(ctx)
+	if db == nil {
+		return p.logger.Error(nil, "Database connection is not available")
+	}
+
+	sqlTx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return p.logger.Error(err, "Failed to begin transaction")
+	}
+
+	tx := &Tx{
+		tx:       sqlTx,
+		ctx:      ctx,
+		rowAlias: p.rowAlias,
+		logger:   p.logger,
+		tokens:   &[]*Token{},
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = sqlTx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := sqlTx.Rollback(); rollbackErr != nil {
+			p.logger.Error(rollbackErr, "Failed to rollback transaction")
+		}
+		return err
+	}
+
+	if err := sqlTx.Commit(); err != nil {
+		p.logger.Error(err, "Failed to commit transaction")
+		return fmt.Errorf("Failed to commit transaction: %w", err)
+	}
+
+	// * GTID is assigned on commit, tracked tokens only held the time until now
+	if len(*tx.tokens) > 0 {
+		token := readToken(ctx, db)
+		for _, tracked := range *tx.tokens {
+			*tracked = token
+		}
+	}
+
+	return nil
+}
+
+// * nested call, mapped to SAVEPOINT / ROLLBACK TO SAVEPOINT
+func (t *Tx) Transaction(fn func(tx *Tx) error) error {
+	savepoint := fmt.Sprintf("sp_%d", t.depth+1)
+
+	if _, err := t.tx.ExecContext(t.ctx, "SAVEPOINT "+savepoint); err != nil {
+		return t.logger.Error(err, "Failed to create savepoint "+savepoint)
+	}
+
+	nested := &Tx{
+		tx:       t.tx,
+		ctx:      t.ctx,
+		depth:    t.depth + 1,
+		rowAlias: t.rowAlias,
+		logger:   t.logger,
+		tokens:   t.tokens,
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = t.tx.ExecContext(t.ctx, "ROLLBACK TO SAVEPOINT "+savepoint)
+			panic(r)
+		}
+	}()
+
+	if err := fn(nested); err != nil {
+		if _, rollbackErr := t.tx.ExecContext(t.ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rollbackErr != nil {
+			t.logger.Error(rollbackErr, "Failed to rollback to savepoint "+savepoint)
+		}
+		return err
+	}
+
+	if _, err := t.tx.ExecContext(t.ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+		t.logger.Error(err, "Failed to release savepoint "+savepoint)
+		return fmt.Errorf("Failed to release savepoint %s: %w", savepoint, err)
+	}
+
+	return nil
+}
+
+func (t *Tx) DB(dbName string) *builder {
+	b := newBuilder(t.tx, t.ctx, dbName, t.logger)
+	b.rowAlias = t.rowAlias
+	b.txTokens = t.tokens
+	return b
+}
+
+// * token is filled with the executed GTID set once Pool.Transaction commits
+func (t *Tx) Track(token *Token) {
+	*token = Token{At: time.Now()}
+	addToken(t.tokens, token)
+}
+
+func (t *Tx) Query(query string, params ...interface{}) (*sql.Rows, error) {
+	return t.QueryContext(t.ctx, query, params...)
+}
+
+func (t *Tx) QueryContext(ctx context.Context, query string, params ...interface{}) (*sql.Rows, error) {
+	startTime := time.Now()
+	rows, err := t.tx.QueryContext(ctx, query, params...)
+	duration := time.Since(startTime)
+
+	if duration > 20*time.Millisecond {
+		t.logger.Info(fmt.Sprintf("Slow Query %s", duration))
+	}
+
+	return rows, err
+}
+
+func (t *Tx) Exec(query string, params ...interface{}) (sql.Result, error) {
+	return t.ExecContext(t.ctx, query, params...)
+}
+
+func (t *Tx) ExecContext(ctx context.Context, query string, params ...interface{}) (sql.Result, error) {
+	startTime := time.Now()
+	result, err := t.tx.ExecContext(ctx, query, params...)
+	duration := time.Since(startTime)
+
+	if duration > 20*time.Millisecond {
+		t.logger.Debug(fmt.Sprintf("Slow Query %s", duration))
+	}
+
+	return result, err
+}
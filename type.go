@@ -1,24 +1,51 @@
 package goMysql
 
 import (
+	"context"
 	"database/sql"
+	"sync/atomic"
+	"time"
 
 	goLogger "github.com/pardnchiu/go-logger"
 )
 
 const (
-	defaultLogPath      = "./logs/goMysql"
-	defaultLogMaxSize   = 16 * 1024 * 1024
-	defaultLogMaxBackup = 5
+	defaultLogPath           = "./logs/goMysql"
+	defaultLogMaxSize        = 16 * 1024 * 1024
+	defaultLogMaxBackup      = 5
+	defaultRetryAttempts     = 3
+	defaultRetryBaseDelay    = 50 * time.Millisecond
+	defaultRetryMaxDelay     = time.Second
+	defaultHealthInterval    = 5 * time.Second
+	defaultHealthTimeout     = 2 * time.Second
+	defaultConsistencyWait   = time.Second
+	defaultConsistencyWindow = 5 * time.Second
+)
+
+var (
+	ErrNoRows = sql.ErrNoRows
+	// * 1213: deadlock, 1205: lock wait timeout
+	defaultRetryCodes = []uint16{1213, 1205}
 )
 
 type Log = goLogger.Log
 type Logger = goLogger.Logger
 
+// * exported alias, so closures outside the package can name the builder
+type Builder = builder
+
 type Config struct {
-	Read  *DBConfig `json:"read,omitempty"`
-	Write *DBConfig `json:"write,omitempty"`
-	Log   *Log      `json:"log,omitempty"`
+	Read *DBConfig `json:"read,omitempty"`
+	// * read replicas, used instead of Read when not empty
+	Reads    []*DBConfig  `json:"reads,omitempty"`
+	Strategy Strategy     `json:"strategy,omitempty"`
+	Write    *DBConfig    `json:"write,omitempty"`
+	Log      *Log         `json:"log,omitempty"`
+	Retry    *RetryConfig `json:"retry,omitempty"`
+	// * replica health check, always running on read pool
+	Health *HealthConfig `json:"health,omitempty"`
+	// * read-your-writes routing for pool.Read.After(token)
+	Consistency *ConsistencyConfig `json:"consistency,omitempty"`
 }
 
 type DBConfig struct {
@@ -28,6 +55,40 @@ type DBConfig struct {
 	Password   string `json:"password,omitempty"`
 	Charset    string `json:"charset,omitempty"`
 	Connection int    `json:"connection,omitempty"`
+	// * relative share for weighted strategy, default 1
+	Weight int `json:"weight,omitempty"`
+}
+
+type RetryConfig struct {
+	MaxAttempts int           `json:"max_attempts,omitempty"`
+	BaseDelay   time.Duration `json:"base_delay,omitempty"`
+	MaxDelay    time.Duration `json:"max_delay,omitempty"`
+	Codes       []uint16      `json:"codes,omitempty"`
+}
+
+type HealthConfig struct {
+	Interval time.Duration `json:"interval,omitempty"`
+	Timeout  time.Duration `json:"timeout,omitempty"`
+	// * replica lagging behind more than MaxLag is skipped, 0 disables lag check
+	MaxLag time.Duration `json:"max_lag,omitempty"`
+	// * pt-heartbeat style table with UTC `ts` column, SHOW REPLICA STATUS is used when empty
+	Heartbeat string `json:"heartbeat,omitempty"`
+}
+
+type ConsistencyConfig struct {
+	// * max time to wait on a replica for the GTID set before reading from primary
+	Wait time.Duration `json:"wait,omitempty"`
+	// * without GTID, reads go to primary within this window after the write
+	Window time.Duration `json:"window,omitempty"`
+}
+
+type ReplicaHealth struct {
+	Name      string        `json:"name"`
+	Healthy   bool          `json:"healthy"`
+	Lagging   bool          `json:"lagging"`
+	Lag       time.Duration `json:"lag"`
+	Error     string        `json:"error,omitempty"`
+	CheckedAt time.Time     `json:"checked_at"`
 }
 
 type PoolList struct {
@@ -38,22 +99,77 @@ type PoolList struct {
 }
 
 type Pool struct {
-	db     *sql.DB
-	logger *Logger
+	db       *sql.DB
+	replicas []*replica
+	strategy Strategy
+	next     atomic.Uint64
+	// * used by read pool when no replica is healthy
+	fallback *sql.DB
+	health   *HealthConfig
+	stop     chan struct{}
+	done     chan struct{}
+	// * set on the pool returned by After
+	parent      *Pool
+	token       Token
+	consistency *ConsistencyConfig
+	retry       *RetryConfig
+	rowAlias    bool
+	logger      *Logger
+}
+
+type Tx struct {
+	tx       *sql.Tx
+	ctx      context.Context
+	depth    int
+	rowAlias bool
+	logger   *Logger
+	// * tracked tokens, shared with nested Tx and filled with GTID after commit
+	tokens *[]*Token
+}
+
+// * shared by *sql.DB and *sql.Tx
+type executor interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+type whereClause struct {
+	boolean string
+	clause  string
 }
 
 type builder struct {
-	db          *sql.DB
+	db          executor
+	ctx         context.Context
 	dbName      *string
 	table       *string
 	selectList  []string
+	fromClause  *string
 	joinList    []string
-	whereList   []string
+	whereList   []whereClause
 	bindingList []interface{}
-	orderList   []string
-	setList     []string
-	limit       *int
-	offset      *int
-	withTotal   bool
-	logger      *Logger
+	// * bindings before WHERE, in SQL order
+	selectBindings []interface{}
+	fromBindings   []interface{}
+	groupList      []string
+	havingList     []whereClause
+	havingBindings []interface{}
+	orderList      []string
+	orderBindings  []interface{}
+	setList        []string
+	setBindings    []interface{}
+	limit          *int
+	offset         *int
+	withTotal      bool
+	distinct       bool
+	rowAlias       bool
+	returnID       *string
+	track          *Token
+	strict         bool
+	err            error
+	logger         *Logger
+	// * set by Pool.After, resolved into db on the first statement
+	after *Pool
+	// * set by Tx.DB, tracked tokens are filled after the transaction commits
+	txTokens *[]*Token
 }
@@ -6,33 +6,41 @@ import (
 	"strings"
 )
 
-func (b *builder) Update(data ...map[string]interface{}) (sql.Result, error) {
+func (b *builder) Update(data ...interface{}) (sql.Result, error) {
 	if b.table == nil {
 		return nil, b.logger.Error(nil, "Table is required")
 	}
 
-	values := []interface{}{}
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	// * Increase / Decrease bindings come first, same order as setList
+	values := append([]interface{}{}, b.setBindings...)
 
 	if len(data) > 0 {
-		for column, value := range data[0] {
+		columns, columnValues, err := b.normalizeData(data[0])
+		if err != nil {
+			return nil, err
+		}
+
+		for i, column := range columns {
+			value := columnValues[i]
 			columnName := column
 			if !strings.Contains(column, ".") {
 				columnName = fmt.Sprintf("`%s`", column)
 			}
 
-			if str, ok := value.(string); ok && contains(supportFunction, strings.ToUpper(str)) {
-				b.setList = append(b.setList, fmt.Sprintf("%s = %s", columnName, str))
-			} else {
-				b.setList = append(b.setList, fmt.Sprintf("%s = ?", columnName))
-				values = append(values, value)
-			}
+			valueSQL, bindings := placeholder(value)
+			b.setList = append(b.setList, fmt.Sprintf("%s = %s", columnName, valueSQL))
+			values = append(values, bindings...)
 		}
 	}
 
-	query := fmt.Sprintf("UPDATE `%s` SET %s", *b.table, strings.Join(b.setList, ", "))
+	query := fmt.Sprintf("UPDATE %s SET %s", b.tableName(), strings.Join(b.setList, ", "))
 
 	if len(b.whereList) > 0 {
-		query += " WHERE " + strings.Join(b.whereList, " AND ")
+		query += " WHERE " + b.whereSQL()
 	}
 
 	allValues := append(values, b.bindingList...)
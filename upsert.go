@@ -2,67 +2,236 @@ package goMysql
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 )
 
-func (b *builder) Upsert(data map[string]interface{}, updateData ...interface{}) (int64, error) {
+type UpsertOutcome int
+
+const (
+	UpsertUnchanged UpsertOutcome = iota
+	UpsertInserted
+	UpsertUpdated
+)
+
+func (o UpsertOutcome) String() string {
+	switch o {
+	case UpsertInserted:
+		return "inserted"
+	case UpsertUpdated:
+		return "updated"
+	default:
+		return "unchanged"
+	}
+}
+
+type UpsertResult struct {
+	Outcome      UpsertOutcome
+	RowsAffected int64
+	ID           int64
+}
+
+type BatchUpsertResult struct {
+	Inserted     int64
+	Updated      int64
+	Unchanged    int64
+	RowsAffected int64
+	IDs          []int64
+}
+
+// * existing row id is reported on update through LAST_INSERT_ID(column)
+func (b *builder) ReturnID(column string) *builder {
+	b.returnID = &column
+	return b
+}
+
+func (b *builder) Upsert(data interface{}, updateData ...interface{}) (*UpsertResult, error) {
+	query, values, err := b.upsertQuery(data, updateData...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := b.exec(query, values...)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	// * MySQL reports 1 for insert, 2 for update and 0 when nothing changed
+	upsertResult := &UpsertResult{RowsAffected: affected}
+	switch affected {
+	case 1:
+		upsertResult.Outcome = UpsertInserted
+	case 2:
+		upsertResult.Outcome = UpsertUpdated
+	default:
+		upsertResult.Outcome = UpsertUnchanged
+	}
+
+	if upsertResult.Outcome == UpsertInserted || b.returnID != nil {
+		if upsertResult.ID, err = result.LastInsertId(); err != nil {
+			return nil, err
+		}
+	}
+
+	return upsertResult, nil
+}
+
+// * each row runs as its own statement, so outcome and id are exact per row
+// * IDs is only filled with ReturnID, in the order of rows
+func (b *builder) UpsertMany(rows []map[string]interface{}, updateData ...interface{}) (*BatchUpsertResult, error) {
 	if b.table == nil {
-		return 0, b.logger.Error(nil, "Table is required")
+		return nil, b.logger.Error(nil, "Table is required")
 	}
 
-	columns := make([]string, 0, len(data))
-	values := make([]interface{}, 0, len(data))
-	placeholders := make([]string, 0, len(data))
+	result := &BatchUpsertResult{}
+	if b.returnID != nil {
+		result.IDs = make([]int64, 0, len(rows))
+	}
+	if len(rows) == 0 {
+		return result, nil
+	}
+
+	err := b.atomic(func(tb *builder) error {
+		for _, row := range rows {
+			rowResult, err := tb.Upsert(row, updateData...)
+			if err != nil {
+				return err
+			}
 
-	for column, value := range data {
-		columns = append(columns, fmt.Sprintf("`%s`", column))
-		values = append(values, value)
-		placeholders = append(placeholders, "?")
+			switch rowResult.Outcome {
+			case UpsertInserted:
+				result.Inserted++
+			case UpsertUpdated:
+				result.Updated++
+			default:
+				result.Unchanged++
+			}
+			result.RowsAffected += rowResult.RowsAffected
+
+			if b.returnID != nil {
+				id := rowResult.ID
+				// * key without AUTO_INCREMENT reports 0 on insert, take it from the row
+				if id == 0 {
+					id = integerValue(row[*b.returnID])
+				}
+				result.IDs = append(result.IDs, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// * private method
+func integerValue(value interface{}) int64 {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	}
+	return 0
+}
+
+// * private method
+func (b *builder) upsertQuery(data interface{}, updateData ...interface{}) (string, []interface{}, error) {
+	if b.table == nil {
+		return "", nil, b.logger.Error(nil, "Table is required")
 	}
 
-	updateClause := ""
+	columnList, columnValues, err := b.normalizeData(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns := make([]string, len(columnList))
+	placeholders := make([]string, len(columnList))
+	values := []interface{}{}
+
+	for i, column := range columnList {
+		valueSQL, bindings := placeholder(columnValues[i])
+		columns[i] = fmt.Sprintf("`%s`", column)
+		placeholders[i] = valueSQL
+		values = append(values, bindings...)
+	}
+
+	updateParts := []string{}
 	updateValues := []interface{}{}
+	alias := ""
+
+	if b.returnID != nil {
+		idColumn := fmt.Sprintf("`%s`", *b.returnID)
+		updateParts = append(updateParts, fmt.Sprintf("%s = LAST_INSERT_ID(%s)", idColumn, idColumn))
+	}
 
 	if len(updateData) > 0 {
 		switch v := updateData[0].(type) {
 		case string:
-			updateClause = fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s", v)
-		case map[string]interface{}:
-			updateParts := []string{}
-			for column, value := range v {
+			updateParts = append(updateParts, v)
+		case []string:
+			alias = b.rowAliasClause()
+			for _, column := range v {
+				columnName := fmt.Sprintf("`%s`", column)
+				updateParts = append(updateParts, fmt.Sprintf("%s = %s", columnName, b.insertedValue(columnName)))
+			}
+		default:
+			updateColumns, updateList, err := b.normalizeData(v)
+			if err != nil {
+				return "", nil, err
+			}
+
+			for i, column := range updateColumns {
+				value := updateList[i]
 				columnName := column
 				if !strings.Contains(column, ".") {
 					columnName = fmt.Sprintf("`%s`", column)
 				}
 
-				if str, ok := value.(string); ok && contains(supportFunction, strings.ToUpper(str)) {
-					updateParts = append(updateParts, fmt.Sprintf("%s = %s", columnName, str))
-				} else {
-					updateParts = append(updateParts, fmt.Sprintf("%s = ?", columnName))
-					updateValues = append(updateValues, value)
-				}
+				valueSQL, bindings := placeholder(value)
+				updateParts = append(updateParts, fmt.Sprintf("%s = %s", columnName, valueSQL))
+				updateValues = append(updateValues, bindings...)
 			}
-			updateClause = fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s", strings.Join(updateParts, ", "))
 		}
 	} else {
-		defaultUpdateParts := make([]string, len(columns))
-		for i, column := range columns {
-			defaultUpdateParts[i] = fmt.Sprintf("%s = VALUES(%s)", column, column)
+		alias = b.rowAliasClause()
+		for _, column := range columns {
+			updateParts = append(updateParts, fmt.Sprintf("%s = %s", column, b.insertedValue(column)))
 		}
-		updateClause = fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s", strings.Join(defaultUpdateParts, ", "))
 	}
 
-	query := fmt.Sprintf("INSERT INTO `%s` (%s) VALUES (%s)%s",
-		*b.table,
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)%s ON DUPLICATE KEY UPDATE %s",
+		b.tableName(),
 		strings.Join(columns, ", "),
 		strings.Join(placeholders, ", "),
-		updateClause)
+		alias,
+		strings.Join(updateParts, ", "))
 
-	allValues := append(values, updateValues...)
-	result, err := b.exec(query, allValues...)
-	if err != nil {
-		return 0, err
+	return query, append(values, updateValues...), nil
+}
+
+// * private method
+// * VALUES() is deprecated since MySQL 8.0.20, use row alias when supported
+func (b *builder) insertedValue(column string) string {
+	if b.rowAlias {
+		return "new." + column
 	}
+	return fmt.Sprintf("VALUES(%s)", column)
+}
 
-	return result.LastInsertId()
+// * private method
+func (b *builder) rowAliasClause() string {
+	if b.rowAlias {
+		return " AS new"
+	}
+	return ""
 }
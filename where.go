@@ -0,0 +1,287 @@
+package goMysql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+func (b *builder) Where(column string, operator interface{}, value ...interface{}) *builder {
+	return b.where("AND", column, operator, value...)
+}
+
+func (b *builder) OrWhere(column string, operator interface{}, value ...interface{}) *builder {
+	return b.where("OR", column, operator, value...)
+}
+
+func (b *builder) WhereGroup(fn func(q *Builder)) *builder {
+	return b.whereGroup("AND", fn)
+}
+
+func (b *builder) OrWhereGroup(fn func(q *Builder)) *builder {
+	return b.whereGroup("OR", fn)
+}
+
+func (b *builder) WhereIn(column string, values interface{}) *builder {
+	return b.whereIn("AND", column, false, values)
+}
+
+func (b *builder) OrWhereIn(column string, values interface{}) *builder {
+	return b.whereIn("OR", column, false, values)
+}
+
+func (b *builder) WhereNotIn(column string, values interface{}) *builder {
+	return b.whereIn("AND", column, true, values)
+}
+
+func (b *builder) OrWhereNotIn(column string, values interface{}) *builder {
+	return b.whereIn("OR", column, true, values)
+}
+
+func (b *builder) WhereNull(column string) *builder {
+	return b.whereNull("AND", column, false)
+}
+
+func (b *builder) OrWhereNull(column string) *builder {
+	return b.whereNull("OR", column, false)
+}
+
+func (b *builder) WhereNotNull(column string) *builder {
+	return b.whereNull("AND", column, true)
+}
+
+func (b *builder) OrWhereNotNull(column string) *builder {
+	return b.whereNull("OR", column, true)
+}
+
+func (b *builder) WhereBetween(column string, from, to interface{}) *builder {
+	return b.whereBetween("AND", column, false, from, to)
+}
+
+func (b *builder) OrWhereBetween(column string, from, to interface{}) *builder {
+	return b.whereBetween("OR", column, false, from, to)
+}
+
+func (b *builder) WhereNotBetween(column string, from, to interface{}) *builder {
+	return b.whereBetween("AND", column, true, from, to)
+}
+
+func (b *builder) OrWhereNotBetween(column string, from, to interface{}) *builder {
+	return b.whereBetween("OR", column, true, from, to)
+}
+
+// * private method
+func (b *builder) where(boolean, column string, operator interface{}, value ...interface{}) *builder {
+	var targetValue interface{}
+	var targetOperator string
+
+	if len(value) == 0 {
+		targetValue = operator
+		targetOperator = "="
+	} else {
+		targetOperator = fmt.Sprintf("%v", operator)
+		targetValue = value[0]
+	}
+
+	if sub, ok := targetValue.(*builder); ok {
+		switch strings.ToUpper(targetOperator) {
+		case "IN", "NOT IN":
+		default:
+			query, bindings, ok := b.subquery(sub)
+			if ok {
+				b.addWhere(boolean, fmt.Sprintf("%s %s (%s)", quoteColumn(column), targetOperator, query), bindings...)
+			}
+			return b
+		}
+	}
+
+	if expr, ok := targetValue.(Expression); ok {
+		switch strings.ToUpper(targetOperator) {
+		case "IN", "NOT IN":
+			b.addWhere(boolean, fmt.Sprintf("%s %s (%s)", quoteColumn(column), targetOperator, expr.sql), expr.bindings...)
+		default:
+			b.addWhere(boolean, fmt.Sprintf("%s %s %s", quoteColumn(column), targetOperator, expr.sql), expr.bindings...)
+		}
+		return b
+	}
+
+	switch strings.ToUpper(targetOperator) {
+	case "IN":
+		return b.whereIn(boolean, column, false, targetValue)
+	case "NOT IN":
+		return b.whereIn(boolean, column, true, targetValue)
+	case "BETWEEN", "NOT BETWEEN":
+		list := expandValues(targetValue)
+		if len(list) != 2 {
+			b.err = b.logger.Error(nil, "BETWEEN requires exactly 2 values on "+column)
+			return b
+		}
+		return b.whereBetween(boolean, column, strings.HasPrefix(strings.ToUpper(targetOperator), "NOT"), list[0], list[1])
+	}
+
+	// * comparing with NULL through = or != never matches
+	if targetValue == nil {
+		switch strings.ToUpper(targetOperator) {
+		case "=", "IS":
+			return b.whereNull(boolean, column, false)
+		case "!=", "<>", "IS NOT":
+			return b.whereNull(boolean, column, true)
+		}
+	}
+
+	if targetOperator == "LIKE" {
+		if str, ok := targetValue.(string); ok {
+			targetValue = fmt.Sprintf("%%%s%%", str)
+		}
+	}
+
+	whereClause := fmt.Sprintf("%s %s ?", quoteColumn(column), targetOperator)
+	b.addWhere(boolean, whereClause, targetValue)
+
+	return b
+}
+
+// * private method
+func (b *builder) whereIn(boolean, column string, not bool, values interface{}) *builder {
+	operator := "IN"
+	if not {
+		operator = "NOT IN"
+	}
+
+	if sub, ok := values.(*builder); ok {
+		query, bindings, ok := b.subquery(sub)
+		if ok {
+			b.addWhere(boolean, fmt.Sprintf("%s %s (%s)", quoteColumn(column), operator, query), bindings...)
+		}
+		return b
+	}
+
+	list := expandValues(values)
+
+	// * empty list never matches for IN, always matches for NOT IN
+	if len(list) == 0 {
+		if not {
+			b.addWhere(boolean, "1 = 1")
+		} else {
+			b.addWhere(boolean, "1 = 0")
+		}
+		return b
+	}
+
+	placeholders := make([]string, len(list))
+	bindings := make([]interface{}, 0, len(list))
+	for i, value := range list {
+		valueSQL, valueBindings := placeholder(value)
+		placeholders[i] = valueSQL
+		bindings = append(bindings, valueBindings...)
+	}
+
+	whereClause := fmt.Sprintf("%s %s (%s)", quoteColumn(column), operator, strings.Join(placeholders, ", "))
+	b.addWhere(boolean, whereClause, bindings...)
+
+	return b
+}
+
+// * private method
+func (b *builder) whereNull(boolean, column string, not bool) *builder {
+	operator := "IS NULL"
+	if not {
+		operator = "IS NOT NULL"
+	}
+
+	b.addWhere(boolean, fmt.Sprintf("%s %s", quoteColumn(column), operator))
+	return b
+}
+
+// * private method
+func (b *builder) whereBetween(boolean, column string, not bool, from, to interface{}) *builder {
+	operator := "BETWEEN"
+	if not {
+		operator = "NOT BETWEEN"
+	}
+
+	fromValue, fromBindings := placeholder(from)
+	toValue, toBindings := placeholder(to)
+	b.addWhere(boolean, fmt.Sprintf("%s %s %s AND %s", quoteColumn(column), operator, fromValue, toValue), append(fromBindings, toBindings...)...)
+	return b
+}
+
+// * private method
+func (b *builder) whereGroup(boolean string, fn func(q *Builder)) *builder {
+	group := &builder{
+		db:     b.db,
+		ctx:    b.ctx,
+		dbName: b.dbName,
+		logger: b.logger,
+	}
+	fn(group)
+
+	if group.err != nil {
+		b.err = group.err
+		return b
+	}
+
+	if len(group.whereList) == 0 {
+		return b
+	}
+
+	b.addWhere(boolean, "("+group.whereSQL()+")", group.bindingList...)
+	return b
+}
+
+// * private method
+func (b *builder) addWhere(boolean, clause string, bindings ...interface{}) {
+	b.whereList = append(b.whereList, whereClause{
+		boolean: boolean,
+		clause:  clause,
+	})
+	b.bindingList = append(b.bindingList, bindings...)
+}
+
+// * private method
+func (b *builder) whereSQL() string {
+	return joinConditions(b.whereList)
+}
+
+// * private method
+func joinConditions(list []whereClause) string {
+	var sb strings.Builder
+	for i, where := range list {
+		if i > 0 {
+			sb.WriteString(" " + where.boolean + " ")
+		}
+		sb.WriteString(where.clause)
+	}
+	return sb.String()
+}
+
+// * private method
+func quoteColumn(column string) string {
+	if !strings.Contains(column, "(") && !strings.Contains(column, ".") {
+		return fmt.Sprintf("`%s`", column)
+	}
+	return column
+}
+
+// * private method
+// * expands any slice or array into values, []byte is kept as a single value
+func expandValues(values interface{}) []interface{} {
+	if values == nil {
+		return nil
+	}
+
+	if _, ok := values.([]byte); ok {
+		return []interface{}{values}
+	}
+
+	value := reflect.ValueOf(values)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return []interface{}{values}
+	}
+
+	list := make([]interface{}, value.Len())
+	for i := range list {
+		list[i] = value.Index(i).Interface()
+	}
+	return list
+}